@@ -0,0 +1,194 @@
+package access
+
+import (
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportAccessTransport = "access_transport"
+
+// Outcome of an information element between the first and the last leg
+const (
+	StatusUnchanged = "unchanged"
+	StatusModified  = "modified"
+	StatusRemoved   = "removed"
+)
+
+// IAMs for the same numbers within this delay are legs of the same call
+const legWindow = 10 * time.Second
+
+// Information elements whose end-to-end transparency is checked
+var trackedElements = []uint8{
+	isup.IELowLayerCompatibility,
+	isup.IEHighLayerCompatibility,
+	isup.IECalledPartySubaddress,
+	isup.IECallingPartySubaddress,
+}
+
+// Leg is one IAM of the call, on one circuit
+type Leg struct {
+	Timestamp time.Time `json:"timestamp"`
+	OPC       uint32    `json:"opc"`
+	DPC       uint32    `json:"dpc"`
+	CIC       uint16    `json:"cic"`
+}
+
+// Element tells what became of an information element along the call
+type Element struct {
+	Name     string `json:"name"`
+	Sent     string `json:"sent"`               // Hex contents in the first IAM
+	Received string `json:"received,omitempty"` // Hex contents in the last IAM
+	Status   string `json:"status,omitempty"`
+}
+
+// Event is a call whose IAM carried compatibility information or subaddresses
+type Event struct {
+	Start         time.Time `json:"start"`
+	CallingNumber string    `json:"calling_number,omitempty"`
+	CalledNumber  string    `json:"called_number,omitempty"`
+	Legs          []Leg     `json:"legs"`
+	FarEndSeen    bool      `json:"far_end_seen"` // The call was seen on more than one circuit
+	Elements      []Element `json:"elements"`
+}
+
+// Report lists the calls carrying Access Transport and the fate of its information elements
+type Report struct {
+	Report string  `json:"report"`
+	Events []Event `json:"events"`
+}
+
+// Calls are matched across circuits on their numbers
+type numbersKey struct {
+	calling string
+	called  string
+}
+
+type callInfo struct {
+	event *Event
+	first map[uint8]string
+	last  map[uint8]string
+	seen  time.Time
+}
+
+// Tracker follows Access Transport along the legs of a call
+type Tracker struct {
+	calls map[numbersKey]*callInfo
+	done  []*callInfo
+}
+
+// NewTracker creates an Access Transport tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		calls: make(map[numbersKey]*callInfo),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil || msg.MessageType != isup.ISUPMessageTypeIAM || msg.IAM == nil {
+		return
+	}
+
+	key := numbersKey{}
+	if msg.IAM.CallingPartyNumber != nil {
+		key.calling = msg.IAM.CallingPartyNumber.Number
+	}
+	if msg.IAM.CalledPartyNumber != nil {
+		key.called = msg.IAM.CalledPartyNumber.Number
+	}
+	leg := Leg{Timestamp: ts, OPC: opc, DPC: dpc, CIC: msg.CIC}
+	elements := trackedContents(msg.IAM.AccessTransport)
+
+	c, exists := t.calls[key]
+	if exists && ts.Sub(c.seen) <= legWindow && !c.onCircuit(leg) {
+		// Next leg of the call
+		c.event.Legs = append(c.event.Legs, leg)
+		c.last = elements
+		c.seen = ts
+		return
+	}
+	if exists {
+		t.done = append(t.done, c)
+		delete(t.calls, key)
+	}
+	if len(elements) == 0 {
+		return
+	}
+
+	t.calls[key] = &callInfo{
+		event: &Event{
+			Start:         ts,
+			CallingNumber: key.calling,
+			CalledNumber:  key.called,
+			Legs:          []Leg{leg},
+		},
+		first: elements,
+		last:  elements,
+		seen:  ts,
+	}
+}
+
+// Finish compares the first and last legs of every call
+func (t *Tracker) Finish() *Report {
+	report := &Report{Report: ReportAccessTransport}
+
+	for _, c := range t.calls {
+		t.done = append(t.done, c)
+	}
+	t.calls = make(map[numbersKey]*callInfo)
+
+	for _, c := range t.done {
+		event := *c.event
+		event.FarEndSeen = len(event.Legs) > 1
+		for _, id := range trackedElements {
+			sent, present := c.first[id]
+			if !present {
+				continue
+			}
+			element := Element{Name: isup.GetInformationElementName(id), Sent: sent}
+			if event.FarEndSeen {
+				received, kept := c.last[id]
+				element.Received = received
+				switch {
+				case !kept:
+					element.Status = StatusRemoved
+				case received == sent:
+					element.Status = StatusUnchanged
+				default:
+					element.Status = StatusModified
+				}
+			}
+			event.Elements = append(event.Elements, element)
+		}
+		report.Events = append(report.Events, event)
+	}
+
+	sort.SliceStable(report.Events, func(i, j int) bool { return report.Events[i].Start.Before(report.Events[j].Start) })
+	return report
+}
+
+// A repeated IAM on a circuit already used is not a new leg
+func (c *callInfo) onCircuit(leg Leg) bool {
+	for _, l := range c.event.Legs {
+		if l.OPC == leg.OPC && l.DPC == leg.DPC && l.CIC == leg.CIC {
+			return true
+		}
+	}
+	return false
+}
+
+// Hex contents of the tracked information elements
+func trackedContents(elements []*isup.InformationElement) map[uint8]string {
+	contents := make(map[uint8]string)
+	for _, ie := range elements {
+		for _, id := range trackedElements {
+			if ie.ID == id {
+				contents[id] = ie.Data
+			}
+		}
+	}
+	return contents
+}
@@ -0,0 +1,964 @@
+package call
+
+import (
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind of a correlated call record
+const ReportCallRecord = "call_record"
+
+// How a suspend interval ended
+const (
+	SuspendEndedResume  = "RES"
+	SuspendEndedRelease = "REL"
+	SuspendEndedT2      = "REL after T2 expiry"
+	SuspendEndedT6      = "REL after T6 expiry"
+	SuspendOpen         = "open"
+)
+
+// Message directions relative to the IAM
+const (
+	DirectionForward  = "forward"
+	DirectionBackward = "backward"
+)
+
+// Diversion reason implied by a call forwarded CPG event
+var forwardedReasons = map[uint8]string{
+	isup.EventCallForwardedBusy:          "user busy",
+	isup.EventCallForwardedNoReply:       "no reply",
+	isup.EventCallForwardedUnconditional: "unconditional",
+}
+
+// Timers used to explain a release during suspension
+type Timers struct {
+	T2 time.Duration // Subscriber-initiated suspension, Q.764
+	T6 time.Duration // Network-initiated suspension, Q.118
+}
+
+// Key identifies a call in progress: the circuit with the lower point code first
+type Key struct {
+	PointCodeA uint32
+	PointCodeB uint32
+	CIC        uint16
+}
+
+// Record is a correlated call, from IAM to RLC
+type Record struct {
+	Report           string            `json:"report"`
+	OPC              uint32            `json:"opc"` // Originating exchange (IAM sender)
+	DPC              uint32            `json:"dpc"`
+	CIC              uint16            `json:"cic"`
+	Start            time.Time         `json:"start"`
+	Answer           *time.Time        `json:"answer,omitempty"`
+	Release          *time.Time        `json:"release,omitempty"`
+	End              *time.Time        `json:"end,omitempty"`
+	Complete         bool              `json:"complete"` // RLC seen
+	CallingNumber    string            `json:"calling_number,omitempty"`
+	CallingSource    string            `json:"calling_source,omitempty"` // Message the effective A-number data comes from
+	CallingCategory  string            `json:"calling_category,omitempty"`
+	ChargeNumber     string            `json:"charge_number,omitempty"`
+	OriginatingLine  *uint8            `json:"originating_line,omitempty"` // ANSI originating line information
+	CalledNumber     string            `json:"called_number,omitempty"`
+	AnsweredSeconds  float64           `json:"answered_seconds,omitempty"`  // Answer to release
+	SuspendedSeconds float64           `json:"suspended_seconds,omitempty"` // Time spent suspended while answered
+	Suspends         []SuspendInterval `json:"suspends,omitempty"`
+	Information      *Information      `json:"information,omitempty"`
+	UserToUser       *UserToUser       `json:"user_to_user,omitempty"`
+	Codecs           *Codecs           `json:"codecs,omitempty"`
+	MCID             *MCID             `json:"mcid,omitempty"`
+	Charging         []ChargingEvent   `json:"charging,omitempty"`
+	Diversion        *Diversion        `json:"diversion,omitempty"`
+	Location         *Location         `json:"location,omitempty"`
+	CUG              *CUG              `json:"cug,omitempty"`
+	Medium           *Medium           `json:"medium,omitempty"`
+	Carrier          *Carrier          `json:"carrier,omitempty"`
+	Portability      *Portability      `json:"portability,omitempty"`
+	IN               *IN               `json:"in,omitempty"`
+	Pivot            *Pivot            `json:"pivot,omitempty"`
+}
+
+// SuspendInterval is one SUS with the message that ended it
+type SuspendInterval struct {
+	Start           time.Time           `json:"start"`
+	End             *time.Time          `json:"end,omitempty"`
+	Direction       string              `json:"direction"`
+	Initiator       string              `json:"initiator"`
+	CallReference   *isup.CallReference `json:"call_reference,omitempty"`
+	EndedBy         string              `json:"ended_by"`
+	DurationSeconds float64             `json:"duration_seconds"`
+
+	networkInitiated bool
+}
+
+// Information is the INR/INF exchange of a call
+type Information struct {
+	Request   *time.Time `json:"request,omitempty"`
+	Response  *time.Time `json:"response,omitempty"`
+	Requested []string   `json:"requested,omitempty"`
+	Returned  []string   `json:"returned,omitempty"`
+	Solicited bool       `json:"solicited"`
+}
+
+// UserToUser is the user-to-user signalling seen along the call
+type UserToUser struct {
+	Messages  []UserToUserMessage `json:"messages,omitempty"`
+	Discarded bool                `json:"discarded"` // Network discard indicated by a User-to-User Indicators
+}
+
+// UserToUserMessage is one User-to-User Information parameter
+type UserToUserMessage struct {
+	Timestamp             time.Time `json:"timestamp"`
+	Message               string    `json:"message"`
+	Direction             string    `json:"direction"`
+	ProtocolDiscriminator string    `json:"protocol_discriminator"`
+	Text                  string    `json:"text,omitempty"`
+	Data                  string    `json:"data,omitempty"`
+}
+
+// Codecs is the BICC codec negotiation carried by the BAT ASE
+type Codecs struct {
+	Offered    []string `json:"offered,omitempty"` // Latest forward codec list
+	Selected   string   `json:"selected,omitempty"`
+	SelectedBy string   `json:"selected_by,omitempty"` // Message carrying the selected codec
+}
+
+// MCID is the malicious call identification exchange (IDR/IDS) of a call
+type MCID struct {
+	Request          *time.Time `json:"request,omitempty"`
+	Response         *time.Time `json:"response,omitempty"`
+	HoldRequested    bool       `json:"hold_requested"`
+	HoldProvided     bool       `json:"hold_provided"`
+	IdentifiedNumber string     `json:"identified_number,omitempty"` // Calling party number returned in the IDS
+	Presentation     string     `json:"presentation,omitempty"`
+	GenericNumber    string     `json:"generic_number,omitempty"`
+}
+
+// ChargingEvent is one charging message (CRG or Charging ASE) seen along the call
+type ChargingEvent struct {
+	Timestamp   time.Time                 `json:"timestamp"`
+	Message     string                    `json:"message"`
+	Direction   string                    `json:"direction"`
+	Information *isup.ChargingInformation `json:"information,omitempty"`
+	National    string                    `json:"national,omitempty"` // Hex, charge information in a national format
+	// Billing data of an ANSI CRG
+	ChargeNumber    string `json:"charge_number,omitempty"`
+	OriginatingLine *uint8 `json:"originating_line,omitempty"`
+	Jurisdiction    string `json:"jurisdiction,omitempty"`
+}
+
+// Diversion is the normalised diversion history of a call
+type Diversion struct {
+	RedirectingNumber    string           `json:"redirecting_number,omitempty"`
+	OriginalCalledNumber string           `json:"original_called_number,omitempty"`
+	OriginalReason       string           `json:"original_reason,omitempty"`
+	Counter              int              `json:"counter"` // Diversions so far, highest counter seen
+	Events               []DiversionEvent `json:"events,omitempty"`
+}
+
+// DiversionEvent is one diversion or redirection reported along the call
+type DiversionEvent struct {
+	Timestamp         time.Time `json:"timestamp"`
+	Message           string    `json:"message"`
+	Direction         string    `json:"direction"`
+	Indicator         string    `json:"indicator,omitempty"`
+	Reason            string    `json:"reason"`
+	RedirectionNumber string    `json:"redirection_number,omitempty"`
+}
+
+// Location is where the calling party is, as signalled in the IAM
+type Location struct {
+	Number            string   `json:"number,omitempty"` // Location number
+	Presentation      string   `json:"presentation,omitempty"`
+	Shape             string   `json:"shape,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty"` // First point of the shape
+	Longitude         *float64 `json:"longitude,omitempty"`
+	UncertaintyMeters *float64 `json:"uncertainty_meters,omitempty"`
+	Screening         string   `json:"screening,omitempty"`
+}
+
+// CUG is the closed user group treatment of a call
+type CUG struct {
+	Call                  string `json:"call"` // CUG call indicator of the IAM
+	OutgoingAccessAllowed bool   `json:"outgoing_access_allowed"`
+	NetworkIdentity       string `json:"network_identity,omitempty"`
+	InterlockCode         uint16 `json:"interlock_code,omitempty"`
+}
+
+// Medium is the transmission medium requested in the IAM and the one used for the call
+type Medium struct {
+	Requested string `json:"requested,omitempty"` // Transmission medium requirement
+	Prime     string `json:"prime,omitempty"`     // Fallback medium offered
+	Used      string `json:"used,omitempty"`
+	UsedBy    string `json:"used_by,omitempty"` // Message carrying the transmission medium used
+	Fallback  bool   `json:"fallback"`
+
+	requested *isup.TransmissionMedium
+	prime     *isup.TransmissionMedium
+}
+
+// Carrier is the carrier selected for the call
+type Carrier struct {
+	Code          string `json:"code"`
+	Source        string `json:"source"` // Parameter the carrier comes from
+	Plan          string `json:"plan,omitempty"`
+	Selection     string `json:"selection,omitempty"` // How the carrier was selected (ANSI)
+	EgressService string `json:"egress_service,omitempty"`
+}
+
+// Portability is the number portability data of a call, with the inconsistencies found
+type Portability struct {
+	DialledNumber   string   `json:"dialled_number,omitempty"`
+	RoutingNumber   string   `json:"routing_number,omitempty"`
+	RoutingSource   string   `json:"routing_source,omitempty"` // Parameter the routing number comes from
+	Translated      bool     `json:"translated"`               // Number translated bit of the forward call indicators
+	Status          string   `json:"status,omitempty"`         // Number portability forward information
+	Jurisdiction    string   `json:"jurisdiction,omitempty"`
+	Inconsistencies []string `json:"inconsistencies,omitempty"`
+}
+
+// IN is the intelligent network treatment of a call that went through a service control function
+type IN struct {
+	Triggered             bool   `json:"triggered"`
+	INNumber              string `json:"in_number,omitempty"`         // Number dialled by the caller, e.g. freephone
+	TranslatedNumber      string `json:"translated_number,omitempty"` // Called party number after translation
+	CalledINNumber        string `json:"called_in_number,omitempty"`
+	OriginalINNumber      string `json:"original_called_in_number,omitempty"`
+	CalledDirectoryNumber string `json:"called_directory_number,omitempty"`
+	CorrelationID         string `json:"correlation_id,omitempty"`
+	SCFID                 string `json:"scf_id,omitempty"`
+}
+
+// Pivot is the pivot routing and query on release treatment of a call
+type Pivot struct {
+	Capability string       `json:"capability,omitempty"` // Pivot capability offered in the IAM
+	QoRCapable bool         `json:"qor_capable"`
+	QoRAttempt bool         `json:"qor_attempt"` // Query on release attempt indicator of the IAM
+	Counter    int          `json:"counter"`     // Pivots so far, highest counter seen
+	Events     []PivotEvent `json:"events,omitempty"`
+}
+
+// PivotEvent is one step of the pivot chain: a pivot request, its outcome, or a release for re-routing
+type PivotEvent struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Message     string    `json:"message"`
+	Direction   string    `json:"direction"`
+	Event       string    `json:"event"`
+	Reason      string    `json:"reason,omitempty"`
+	Destination string    `json:"destination,omitempty"` // Number the call is pivoted or re-routed to
+}
+
+// Correlator groups ISUP messages into calls
+type Correlator struct {
+	timers Timers
+	calls  map[Key]*Record
+}
+
+// NewCorrelator creates a call correlator
+func NewCorrelator(timers Timers) *Correlator {
+	return &Correlator{
+		timers: timers,
+		calls:  make(map[Key]*Record),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc, returning the call record once the call is complete
+func (c *Correlator) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) *Record {
+	if msg == nil {
+		return nil
+	}
+	key := newKey(opc, dpc, msg.CIC)
+
+	if msg.MessageType == isup.ISUPMessageTypeIAM {
+		// A new IAM replaces whatever was left on the circuit
+		rec := &Record{
+			Report: ReportCallRecord,
+			OPC:    opc,
+			DPC:    dpc,
+			CIC:    msg.CIC,
+			Start:  ts,
+		}
+		if msg.IAM != nil {
+			rec.CallingSource = "IAM"
+			if msg.IAM.CallingPartyNumber != nil {
+				rec.CallingNumber = msg.IAM.CallingPartyNumber.Number
+			}
+			if msg.IAM.CallingPartyCategory != nil {
+				rec.CallingCategory = msg.IAM.CallingPartyCategory.Name
+			}
+			if msg.IAM.ChargeNumber != nil {
+				rec.ChargeNumber = msg.IAM.ChargeNumber.Number
+			}
+			if msg.IAM.OriginatingLineInformation != nil {
+				oli := msg.IAM.OriginatingLineInformation.Value
+				rec.OriginatingLine = &oli
+			}
+			if msg.IAM.CalledPartyNumber != nil {
+				rec.CalledNumber = msg.IAM.CalledPartyNumber.Number
+			}
+			rec.Location = newLocation(msg.IAM.LocationNumber, msg.IAM.CallingGeodeticLocation)
+			rec.CUG = newCUG(msg.IAM.OptionalForwardCall, msg.IAM.CUGInterlockCode)
+			rec.Carrier = newCarrier(msg.IAM)
+			rec.Portability = newPortability(msg.IAM)
+			rec.IN = newIN(msg.IAM)
+			rec.Pivot = newPivot(msg.IAM)
+			if msg.IAM.TransmissionMedium != nil {
+				rec.Medium = &Medium{
+					Requested: msg.IAM.TransmissionMedium.Name,
+					requested: msg.IAM.TransmissionMedium,
+					prime:     msg.IAM.TransmissionMediumPrime,
+				}
+				if msg.IAM.TransmissionMediumPrime != nil {
+					rec.Medium.Prime = msg.IAM.TransmissionMediumPrime.Name
+				}
+			}
+			rec.addUserToUser(ts, msg, DirectionForward, msg.IAM.UserToUserIndicators, msg.IAM.UserToUserInformation)
+		}
+		rec.addCodecs(msg, DirectionForward)
+		rec.addCharging(ts, msg, DirectionForward)
+		rec.addDiversion(ts, msg, DirectionForward)
+		c.calls[key] = rec
+		return nil
+	}
+
+	rec, exists := c.calls[key]
+	if !exists {
+		return nil
+	}
+	direction := DirectionForward
+	if opc != rec.OPC {
+		direction = DirectionBackward
+	}
+	// A PAM passes a message along the established call: its data belongs to the call,
+	// but it is no call control event on this circuit
+	if msg.MessageType == isup.ISUPMessageTypePAM {
+		if msg.PAM != nil {
+			rec.addPassedAlong(ts, msg.PAM, direction)
+		}
+		return nil
+	}
+	rec.addCodecs(msg, direction)
+	rec.addCharging(ts, msg, direction)
+	rec.addDiversion(ts, msg, direction)
+	rec.addMediumUsed(msg)
+	rec.addPivot(ts, msg, direction)
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeANM, isup.ISUPMessageTypeCON:
+		if rec.Answer == nil {
+			rec.Answer = &ts
+		}
+		if msg.ANM != nil {
+			rec.addUserToUser(ts, msg, direction, msg.ANM.UserToUserIndicators, msg.ANM.UserToUserInformation)
+		}
+	case isup.ISUPMessageTypeACM:
+		if msg.ACM != nil {
+			rec.addUserToUser(ts, msg, direction, msg.ACM.UserToUserIndicators, msg.ACM.UserToUserInformation)
+		}
+	case isup.ISUPMessageTypeUSR:
+		if msg.USR != nil {
+			rec.addUserToUser(ts, msg, direction, nil, msg.USR.UserToUserInformation)
+		}
+	case isup.ISUPMessageTypeSUS:
+		interval := SuspendInterval{
+			Start:     ts,
+			Direction: direction,
+			EndedBy:   SuspendOpen,
+		}
+		if msg.SuspendResume != nil {
+			interval.Initiator = msg.SuspendResume.IndicatorName
+			interval.CallReference = msg.SuspendResume.CallReference
+			interval.networkInitiated = msg.SuspendResume.Indicator == 0x01
+		}
+		rec.Suspends = append(rec.Suspends, interval)
+	case isup.ISUPMessageTypeRES:
+		c.closeSuspend(rec, ts, SuspendEndedResume)
+	case isup.ISUPMessageTypeINR:
+		if rec.Information == nil {
+			rec.Information = &Information{}
+		}
+		rec.Information.Request = &ts
+		if msg.INR != nil && msg.INR.Indicators != nil {
+			rec.Information.Requested = msg.INR.Indicators.Requested()
+		}
+	case isup.ISUPMessageTypeINF:
+		if rec.Information == nil {
+			rec.Information = &Information{}
+		}
+		rec.Information.Response = &ts
+		if msg.INF != nil {
+			rec.mergeInformation(msg.INF)
+		}
+	case isup.ISUPMessageTypeIDR:
+		if rec.MCID == nil {
+			rec.MCID = &MCID{}
+		}
+		rec.MCID.Request = &ts
+		if msg.IDR != nil && msg.IDR.Indicators != nil {
+			rec.MCID.HoldRequested = msg.IDR.Indicators.Holding == 1
+		}
+	case isup.ISUPMessageTypeIDS:
+		if rec.MCID == nil {
+			rec.MCID = &MCID{}
+		}
+		rec.MCID.Response = &ts
+		if msg.IDS != nil {
+			if msg.IDS.Indicators != nil {
+				rec.MCID.HoldProvided = msg.IDS.Indicators.HoldProvided == 1
+			}
+			if msg.IDS.CallingPartyNumber != nil {
+				rec.MCID.IdentifiedNumber = msg.IDS.CallingPartyNumber.Number
+				rec.MCID.Presentation = msg.IDS.CallingPartyNumber.RestrictName
+			}
+			if msg.IDS.GenericNumber != nil {
+				rec.MCID.GenericNumber = msg.IDS.GenericNumber.Number
+			}
+		}
+	case isup.ISUPMessageTypeREL:
+		if rec.Release == nil {
+			rec.Release = &ts
+		}
+		if msg.REL != nil {
+			rec.addUserToUser(ts, msg, direction, msg.REL.UserToUserIndicators, msg.REL.UserToUserInformation)
+		}
+		c.closeSuspend(rec, ts, SuspendEndedRelease)
+	case isup.ISUPMessageTypeRLC:
+		rec.End = &ts
+		rec.Complete = true
+		delete(c.calls, key)
+		rec.finish()
+		return rec
+	}
+
+	return nil
+}
+
+// Finish returns the calls still in progress at the end of the capture
+func (c *Correlator) Finish() []*Record {
+	records := make([]*Record, 0, len(c.calls))
+	for key, rec := range c.calls {
+		rec.finish()
+		records = append(records, rec)
+		delete(c.calls, key)
+	}
+	return records
+}
+
+func newKey(opc, dpc uint32, cic uint16) Key {
+	if opc > dpc {
+		opc, dpc = dpc, opc
+	}
+	return Key{PointCodeA: opc, PointCodeB: dpc, CIC: cic}
+}
+
+// Close the open suspend interval, telling a timer expiry from a plain release
+func (c *Correlator) closeSuspend(rec *Record, ts time.Time, endedBy string) {
+	if len(rec.Suspends) == 0 {
+		return
+	}
+	interval := &rec.Suspends[len(rec.Suspends)-1]
+	if interval.End != nil {
+		return
+	}
+
+	elapsed := ts.Sub(interval.Start)
+	if endedBy == SuspendEndedRelease {
+		if interval.networkInitiated && c.timers.T6 > 0 && elapsed >= c.timers.T6 {
+			endedBy = SuspendEndedT6
+		} else if !interval.networkInitiated && c.timers.T2 > 0 && elapsed >= c.timers.T2 {
+			endedBy = SuspendEndedT2
+		}
+	}
+
+	interval.End = &ts
+	interval.EndedBy = endedBy
+	interval.DurationSeconds = elapsed.Seconds()
+}
+
+// Merge the A-number data returned in an INF. Solicited values replace the IAM
+// ones, unsolicited values only fill what the IAM left out.
+func (rec *Record) mergeInformation(inf *isup.INFParameters) {
+	solicited := inf.Indicators != nil && inf.Indicators.Solicited == 0
+	rec.Information.Solicited = solicited
+	rec.Information.Returned = nil
+
+	if inf.CallingPartyNumber != nil && inf.CallingPartyNumber.Number != "" {
+		rec.Information.Returned = append(rec.Information.Returned, "calling party address")
+		if solicited || rec.CallingNumber == "" {
+			rec.CallingNumber = inf.CallingPartyNumber.Number
+			rec.CallingSource = "INF"
+		}
+	}
+	if inf.CallingPartyCategory != nil {
+		rec.Information.Returned = append(rec.Information.Returned, "calling party's category")
+		if solicited || rec.CallingCategory == "" {
+			rec.CallingCategory = inf.CallingPartyCategory.Name
+			rec.CallingSource = "INF"
+		}
+	}
+	if inf.ChargeNumber != nil && inf.ChargeNumber.Number != "" {
+		rec.Information.Returned = append(rec.Information.Returned, "charge information")
+		if solicited || rec.ChargeNumber == "" {
+			rec.ChargeNumber = inf.ChargeNumber.Number
+			rec.CallingSource = "INF"
+		}
+	}
+}
+
+// Record the user-to-user information and network discard indication carried by a message
+func (rec *Record) addUserToUser(ts time.Time, msg *isup.ISUPMessage, direction string, ind *isup.UserToUserIndicators, uui *isup.UserToUserInformation) {
+	if ind == nil && uui == nil {
+		return
+	}
+	if rec.UserToUser == nil {
+		rec.UserToUser = &UserToUser{}
+	}
+
+	if ind != nil && ind.NetworkDiscard == 0x01 {
+		rec.UserToUser.Discarded = true
+	}
+	if uui != nil {
+		rec.UserToUser.Messages = append(rec.UserToUser.Messages, UserToUserMessage{
+			Timestamp:             ts,
+			Message:               msg.MessageName,
+			Direction:             direction,
+			ProtocolDiscriminator: uui.ProtocolDiscriminatorName,
+			Text:                  uui.Text,
+			Data:                  uui.Data,
+		})
+	}
+}
+
+// Build the location of the calling party from a Location Number and a Calling Geodetic Location
+func newLocation(number *isup.NumberInfoLocation, geodetic *isup.CallingGeodeticLocation) *Location {
+	if number == nil && geodetic == nil {
+		return nil
+	}
+	location := &Location{}
+	if number != nil {
+		location.Number = number.Number
+		location.Presentation = number.RestrictName
+	}
+	if geodetic != nil {
+		location.Shape = geodetic.ShapeName
+		location.Screening = geodetic.ScreenedName
+		if len(geodetic.Points) > 0 {
+			location.Latitude = &geodetic.Points[0].Latitude
+			location.Longitude = &geodetic.Points[0].Longitude
+		}
+		location.UncertaintyMeters = geodetic.UncertaintyMeters
+		if location.Presentation == "" {
+			location.Presentation = geodetic.RestrictName
+		}
+	}
+	return location
+}
+
+// Build the closed user group treatment from the Optional Forward Call Indicators and the interlock code
+func newCUG(indicators *isup.OptionalForwardCall, interlock *isup.CUGInterlockCode) *CUG {
+	if interlock == nil && (indicators == nil || indicators.CUGCall == isup.CUGCallNone) {
+		return nil
+	}
+	cug := &CUG{}
+	if indicators != nil {
+		cug.Call = indicators.CUGCallName
+		cug.OutgoingAccessAllowed = indicators.CUGCall == isup.CUGCallOutgoingAccessAllowed
+	}
+	if interlock != nil {
+		cug.NetworkIdentity = interlock.NetworkIdentity
+		cug.InterlockCode = interlock.BinaryCode
+	}
+	return cug
+}
+
+// Build the number portability data of the call from the IAM: an ANSI LRN translation moves the
+// dialled number to a Generic Address (ported number), ITU-T adds a Network Routing Number
+func newPortability(iam *isup.IAMParameters) *Portability {
+	ported := iam.PortedNumber()
+	nrn := iam.NetworkRoutingNumber
+	translated := iam.ForwardCall != nil && iam.ForwardCall.PortedNumber == 1
+	if !translated && ported == nil && nrn == nil && iam.NumberPortabilityForwardInformation == nil {
+		return nil
+	}
+
+	portability := &Portability{Translated: translated}
+	if iam.CalledPartyNumber != nil {
+		portability.DialledNumber = iam.CalledPartyNumber.Number
+	}
+	switch {
+	case ported != nil:
+		portability.DialledNumber = ported.Number
+		if iam.CalledPartyNumber != nil {
+			portability.RoutingNumber = iam.CalledPartyNumber.Number
+		}
+		portability.RoutingSource = isup.GetParameterName(isup.ISUPCalledPartyNumber)
+	case nrn != nil:
+		portability.RoutingNumber = nrn.Number
+		portability.RoutingSource = isup.GetParameterName(isup.ISUPNetworkRoutingNumber)
+	}
+	if iam.NumberPortabilityForwardInformation != nil {
+		portability.Status = iam.NumberPortabilityForwardInformation.StatusName
+	}
+	if iam.Jurisdiction != nil {
+		portability.Jurisdiction = *iam.Jurisdiction
+	}
+
+	// Consistency between the translation indicator and the portability parameters
+	if translated && ported == nil && nrn == nil {
+		portability.Inconsistencies = append(portability.Inconsistencies, "number translated without generic address or network routing number")
+	}
+	if !translated && ported != nil {
+		portability.Inconsistencies = append(portability.Inconsistencies, "generic address (ported number) on an untranslated call")
+	}
+	if npfi := iam.NumberPortabilityForwardInformation; npfi != nil && npfi.Status == isup.PortabilityQueryDonePorted && ported == nil && nrn == nil {
+		portability.Inconsistencies = append(portability.Inconsistencies, "ported subscriber without routing number")
+	}
+
+	return portability
+}
+
+// Build the intelligent network treatment from the IAM, the original IN number being the one dialled
+func newIN(iam *isup.IAMParameters) *IN {
+	if !iam.INTriggered() {
+		return nil
+	}
+	in := &IN{Triggered: true, SCFID: iam.SCFID}
+	if iam.CalledPartyNumber != nil {
+		in.TranslatedNumber = iam.CalledPartyNumber.Number
+	}
+	if iam.CalledINNumber != nil {
+		in.CalledINNumber = iam.CalledINNumber.Number
+		in.INNumber = in.CalledINNumber
+	}
+	if iam.OriginalCalledINNumber != nil {
+		in.OriginalINNumber = iam.OriginalCalledINNumber.Number
+		in.INNumber = in.OriginalINNumber
+	}
+	if iam.CalledDirectoryNumber != nil {
+		in.CalledDirectoryNumber = iam.CalledDirectoryNumber.Number
+	}
+	if iam.CorrelationID != nil {
+		in.CorrelationID = iam.CorrelationID.Digits
+		if in.CorrelationID == "" {
+			in.CorrelationID = iam.CorrelationID.Data
+		}
+	}
+	return in
+}
+
+// Build the carrier selected for the call from the IAM
+func newCarrier(iam *isup.IAMParameters) *Carrier {
+	selected := iam.SelectedCarrier()
+	if selected == nil {
+		return nil
+	}
+	carrier := &Carrier{
+		Code:   selected.Network,
+		Source: isup.GetParameterName(isup.ISUPTransitNetworkSelection),
+		Plan:   selected.PlanName,
+	}
+	if selected == iam.CarrierIdentification {
+		carrier.Source = isup.GetParameterName(isup.ISUPCarrierIdentification)
+	}
+	if iam.CarrierSelection != nil {
+		carrier.Selection = iam.CarrierSelection.Name
+	}
+	if iam.EgressService != nil {
+		carrier.EgressService = iam.EgressService.Text
+		if carrier.EgressService == "" {
+			carrier.EgressService = iam.EgressService.Data
+		}
+	}
+	return carrier
+}
+
+// Record the Transmission Medium Used returned by the far end and tell whether it fell back
+func (rec *Record) addMediumUsed(msg *isup.ISUPMessage) {
+	var used *isup.TransmissionMedium
+	switch {
+	case msg.ACM != nil:
+		used = msg.ACM.TransmissionMediumUsed
+	case msg.ANM != nil:
+		used = msg.ANM.TransmissionMediumUsed
+	case msg.CPG != nil:
+		used = msg.CPG.TransmissionMediumUsed
+	}
+	if used == nil || rec.Medium == nil {
+		return
+	}
+
+	rec.Medium.Used = used.Name
+	rec.Medium.UsedBy = msg.MessageName
+	switch {
+	case rec.Medium.prime != nil:
+		rec.Medium.Fallback = used.Num == rec.Medium.prime.Num && used.Num != rec.Medium.requested.Num
+	default:
+		rec.Medium.Fallback = used.Num != rec.Medium.requested.Num
+	}
+}
+
+// Record the codecs offered and selected in the BAT data carried by a message.
+// A single codec is the selected one, as is the preferred codec of a backward list.
+func (rec *Record) addCodecs(msg *isup.ISUPMessage, direction string) {
+	for _, app := range msg.ApplicationTransports() {
+		bat := app.BAT
+		if bat == nil || (bat.SingleCodec == nil && len(bat.CodecList) == 0) {
+			continue
+		}
+		if rec.Codecs == nil {
+			rec.Codecs = &Codecs{}
+		}
+
+		switch {
+		case bat.SingleCodec != nil:
+			rec.Codecs.Selected = bat.SingleCodec.Name
+			rec.Codecs.SelectedBy = msg.MessageName
+		case direction == DirectionForward:
+			rec.Codecs.Offered = rec.Codecs.Offered[:0]
+			for _, codec := range bat.CodecList {
+				rec.Codecs.Offered = append(rec.Codecs.Offered, codec.Name)
+			}
+		default:
+			rec.Codecs.Selected = bat.CodecList[0].Name
+			rec.Codecs.SelectedBy = msg.MessageName
+		}
+	}
+}
+
+// Record the charging information carried by a message, to be checked against the call duration
+func (rec *Record) addCharging(ts time.Time, msg *isup.ISUPMessage, direction string) {
+	if msg.CRG != nil && msg.CRG.National != "" {
+		rec.Charging = append(rec.Charging, ChargingEvent{
+			Timestamp: ts,
+			Message:   msg.MessageName,
+			Direction: direction,
+			National:  msg.CRG.National,
+		})
+	}
+	if crg := msg.CRG; crg != nil && (crg.ChargeNumber != nil || crg.OriginatingLineInformation != nil || crg.Jurisdiction != nil) {
+		event := ChargingEvent{Timestamp: ts, Message: msg.MessageName, Direction: direction}
+		if crg.ChargeNumber != nil {
+			event.ChargeNumber = crg.ChargeNumber.Number
+		}
+		if crg.OriginatingLineInformation != nil {
+			oli := crg.OriginatingLineInformation.Value
+			event.OriginatingLine = &oli
+		}
+		if crg.Jurisdiction != nil {
+			event.Jurisdiction = *crg.Jurisdiction
+		}
+		rec.Charging = append(rec.Charging, event)
+	}
+	for _, app := range msg.ApplicationTransports() {
+		if app.Charging == nil {
+			continue
+		}
+		rec.Charging = append(rec.Charging, ChargingEvent{
+			Timestamp:   ts,
+			Message:     msg.MessageName,
+			Direction:   direction,
+			Information: app.Charging,
+		})
+	}
+}
+
+// Record the data of a message passed along in a PAM, leaving the call state as it is
+func (rec *Record) addPassedAlong(ts time.Time, msg *isup.ISUPMessage, direction string) {
+	rec.addCodecs(msg, direction)
+	rec.addCharging(ts, msg, direction)
+	rec.addDiversion(ts, msg, direction)
+	rec.addMediumUsed(msg)
+	rec.addPivot(ts, msg, direction)
+
+	switch {
+	case msg.IAM != nil:
+		rec.addUserToUser(ts, msg, direction, msg.IAM.UserToUserIndicators, msg.IAM.UserToUserInformation)
+	case msg.ACM != nil:
+		rec.addUserToUser(ts, msg, direction, msg.ACM.UserToUserIndicators, msg.ACM.UserToUserInformation)
+	case msg.ANM != nil:
+		rec.addUserToUser(ts, msg, direction, msg.ANM.UserToUserIndicators, msg.ANM.UserToUserInformation)
+	case msg.REL != nil:
+		rec.addUserToUser(ts, msg, direction, msg.REL.UserToUserIndicators, msg.REL.UserToUserInformation)
+	case msg.USR != nil:
+		rec.addUserToUser(ts, msg, direction, nil, msg.USR.UserToUserInformation)
+	}
+}
+
+// Record the diversions reported by a message: the redirection information of an
+// IAM or REL, a call forwarded CPG, or the call diversion information of an ACM/CPG
+func (rec *Record) addDiversion(ts time.Time, msg *isup.ISUPMessage, direction string) {
+	event := DiversionEvent{Timestamp: ts, Message: msg.MessageName, Direction: direction}
+	var info *isup.RedirectionInformation
+	var cdi *isup.CallDiversionInformation
+	var redirection *isup.NumberInfoCalled
+	var counter *uint8
+	forwarded := false
+
+	switch {
+	case msg.IAM != nil:
+		iam := msg.IAM
+		if iam.RedirectingNumber != nil || iam.OriginalCalledNumber != nil || iam.RedirectionInformation != nil {
+			rec.diversion()
+			if iam.RedirectingNumber != nil {
+				rec.Diversion.RedirectingNumber = iam.RedirectingNumber.Number
+			}
+			if iam.OriginalCalledNumber != nil {
+				rec.Diversion.OriginalCalledNumber = iam.OriginalCalledNumber.Number
+			}
+			if iam.RedirectionInformation != nil {
+				rec.Diversion.OriginalReason = iam.RedirectionInformation.OriginalReasonName
+			}
+		}
+		info, counter = iam.RedirectionInformation, iam.RedirectCounter
+	case msg.ACM != nil:
+		cdi, redirection = msg.ACM.CallDiversionInformation, msg.ACM.RedirectionNumber
+	case msg.CPG != nil:
+		cdi, redirection = msg.CPG.CallDiversionInformation, msg.CPG.RedirectionNumber
+		if msg.CPG.EventInformation != nil {
+			switch msg.CPG.EventInformation.Event {
+			case isup.EventCallForwardedBusy, isup.EventCallForwardedNoReply, isup.EventCallForwardedUnconditional:
+				forwarded = true
+				event.Indicator = msg.CPG.EventInformation.EventName
+			}
+		}
+	case msg.REL != nil:
+		info, redirection, counter = msg.REL.RedirectionInformation, msg.REL.RedirectionNumber, msg.REL.RedirectCounter
+	}
+
+	if info == nil && cdi == nil && !forwarded {
+		if counter != nil {
+			rec.diversion().raiseCounter(int(*counter))
+		}
+		return
+	}
+	rec.diversion()
+	if info != nil {
+		event.Indicator = info.IndicatorName
+		event.Reason = info.ReasonName
+		rec.Diversion.raiseCounter(int(info.Counter))
+	}
+	if cdi != nil {
+		event.Reason = cdi.ReasonName
+	}
+	if counter != nil {
+		rec.Diversion.raiseCounter(int(*counter))
+	}
+	if forwarded && event.Reason == "" {
+		event.Reason = forwardedReasons[msg.CPG.EventInformation.Event]
+	}
+	if redirection != nil {
+		event.RedirectionNumber = redirection.Number
+	}
+	rec.Diversion.Events = append(rec.Diversion.Events, event)
+}
+
+// Pivot and query on release capabilities offered by the IAM, nil when there are none
+func newPivot(iam *isup.IAMParameters) *Pivot {
+	qorAttempt := iam.ForwardCall != nil && iam.ForwardCall.QueryOnRelease == 1
+	if iam.PivotCapability == nil && iam.QoRCapability == nil && iam.PivotCounter == nil && !qorAttempt {
+		return nil
+	}
+
+	pivot := &Pivot{QoRAttempt: qorAttempt}
+	if iam.PivotCapability != nil {
+		pivot.Capability = iam.PivotCapability.CapabilityName
+	}
+	if iam.QoRCapability != nil {
+		pivot.QoRCapable = iam.QoRCapability.Capability == 1
+	}
+	if iam.PivotCounter != nil {
+		pivot.Counter = int(*iam.PivotCounter)
+	}
+	return pivot
+}
+
+// Record the pivot chain: pivot routing requested or acknowledged in a FAC,
+// or a release asking the previous exchange to re-route after a query on release
+func (rec *Record) addPivot(ts time.Time, msg *isup.ISUPMessage, direction string) {
+	event := PivotEvent{Timestamp: ts, Message: msg.MessageName, Direction: direction}
+
+	switch {
+	case msg.MessageType == isup.ISUPMessageTypeFAC && msg.Facility != nil:
+		fac := msg.Facility
+		if fac.PivotRoutingIndicators == nil && fac.PivotStatus == nil {
+			return
+		}
+		if fac.PivotCounter != nil {
+			rec.pivot().raiseCounter(int(*fac.PivotCounter))
+		}
+		if fac.RedirectionNumber != nil {
+			event.Destination = fac.RedirectionNumber.Number
+		}
+		if fac.PivotRoutingIndicators != nil {
+			event.Event = fac.PivotRoutingIndicators.IndicatorName
+		}
+		if fac.PivotStatus != nil {
+			if event.Event != "" {
+				event.Reason = fac.PivotStatus.StatusName
+			} else {
+				event.Event = fac.PivotStatus.StatusName
+			}
+		}
+	case msg.REL != nil:
+		if msg.REL.Cause == nil || msg.REL.Cause.CauseValue != isup.CauseQoRPortedNumber {
+			return
+		}
+		event.Event = "released for re-routing"
+		event.Reason = msg.REL.Cause.CauseName
+		if msg.REL.RedirectionNumber != nil {
+			event.Destination = msg.REL.RedirectionNumber.Number
+		}
+	default:
+		return
+	}
+
+	pivot := rec.pivot()
+	pivot.Events = append(pivot.Events, event)
+}
+
+func (rec *Record) pivot() *Pivot {
+	if rec.Pivot == nil {
+		rec.Pivot = &Pivot{}
+	}
+	return rec.Pivot
+}
+
+func (p *Pivot) raiseCounter(counter int) {
+	if counter > p.Counter {
+		p.Counter = counter
+	}
+}
+
+func (rec *Record) diversion() *Diversion {
+	if rec.Diversion == nil {
+		rec.Diversion = &Diversion{}
+	}
+	return rec.Diversion
+}
+
+func (d *Diversion) raiseCounter(counter int) {
+	if counter > d.Counter {
+		d.Counter = counter
+	}
+}
+
+// Compute the durations once the call is over
+func (rec *Record) finish() {
+	if rec.Answer == nil {
+		return
+	}
+
+	end := rec.Release
+	if end == nil {
+		end = rec.End
+	}
+	if end != nil {
+		rec.AnsweredSeconds = end.Sub(*rec.Answer).Seconds()
+	}
+
+	rec.SuspendedSeconds = 0
+	for _, interval := range rec.Suspends {
+		rec.SuspendedSeconds += interval.DurationSeconds
+	}
+}
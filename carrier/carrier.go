@@ -0,0 +1,117 @@
+package carrier
+
+import (
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportCarrier = "carrier"
+
+// Calls without any carrier selection parameter
+const noCarrier = "none"
+
+// Summary is the traffic handed to one carrier
+type Summary struct {
+	Carrier         string         `json:"carrier"` // Carrier code or network identification
+	Calls           int            `json:"calls"`
+	Answered        int            `json:"answered"`
+	AnsweredSeconds float64        `json:"answered_seconds"` // Answer to release, released calls only
+	Selections      map[string]int `json:"selections,omitempty"`
+}
+
+// Report summarises traffic per selected carrier
+type Report struct {
+	Report   string     `json:"report"`
+	Carriers []*Summary `json:"carriers"`
+}
+
+type circuitKey struct {
+	pcA uint32
+	pcB uint32
+	cic uint16
+}
+
+// Call on a circuit, as announced by its IAM
+type callInfo struct {
+	summary *Summary
+	answer  *time.Time
+}
+
+// Tracker counts calls and answered time per selected carrier
+type Tracker struct {
+	calls     map[circuitKey]*callInfo
+	summaries map[string]*Summary
+}
+
+// NewTracker creates a carrier tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		calls:     make(map[circuitKey]*callInfo),
+		summaries: make(map[string]*Summary),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+	pcA, pcB := opc, dpc
+	if pcA > pcB {
+		pcA, pcB = pcB, pcA
+	}
+	key := circuitKey{pcA: pcA, pcB: pcB, cic: msg.CIC}
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeIAM:
+		if msg.IAM == nil {
+			delete(t.calls, key)
+			return
+		}
+		code := noCarrier
+		if selected := msg.IAM.SelectedCarrier(); selected != nil && selected.Network != "" {
+			code = selected.Network
+		}
+		summary, exists := t.summaries[code]
+		if !exists {
+			summary = &Summary{Carrier: code}
+			t.summaries[code] = summary
+		}
+		summary.Calls++
+		if msg.IAM.CarrierSelection != nil {
+			if summary.Selections == nil {
+				summary.Selections = make(map[string]int)
+			}
+			summary.Selections[msg.IAM.CarrierSelection.Name]++
+		}
+		t.calls[key] = &callInfo{summary: summary}
+	case isup.ISUPMessageTypeANM, isup.ISUPMessageTypeCON:
+		if c, exists := t.calls[key]; exists && c.answer == nil {
+			c.answer = &ts
+			c.summary.Answered++
+		}
+	case isup.ISUPMessageTypeREL, isup.ISUPMessageTypeRSC:
+		if c, exists := t.calls[key]; exists && c.answer != nil {
+			c.summary.AnsweredSeconds += ts.Sub(*c.answer).Seconds()
+		}
+		delete(t.calls, key)
+	}
+}
+
+// Finish returns the traffic per carrier, busiest first
+func (t *Tracker) Finish() *Report {
+	report := &Report{Report: ReportCarrier}
+	for _, summary := range t.summaries {
+		report.Carriers = append(report.Carriers, summary)
+	}
+	sort.Slice(report.Carriers, func(i, j int) bool {
+		if report.Carriers[i].Calls != report.Carriers[j].Calls {
+			return report.Carriers[i].Calls > report.Carriers[j].Calls
+		}
+		return report.Carriers[i].Carrier < report.Carriers[j].Carrier
+	})
+	return report
+}
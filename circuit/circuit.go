@@ -0,0 +1,370 @@
+package circuit
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Circuit states, from the point of view of the trunk OPC
+const (
+	StateIdle                       = "idle"
+	StateBusyIncoming               = "busy_incoming"
+	StateBusyOutgoing               = "busy_outgoing"
+	StateLocallyBlockedMaintenance  = "locally_blocked_maintenance"
+	StateRemotelyBlockedMaintenance = "remotely_blocked_maintenance"
+	StateLocallyBlockedHardware     = "locally_blocked_hardware"
+	StateRemotelyBlockedHardware    = "remotely_blocked_hardware"
+	StateUnequipped                 = "unequipped"
+)
+
+// Report kinds
+const (
+	ReportSnapshot = "circuit_snapshot"
+	ReportFinal    = "circuit_final"
+)
+
+// Sides of a trunk: the lower point code is the local side
+const (
+	sideLocal  = 0
+	sideRemote = 1
+)
+
+// Key identifies a circuit. OPC is always the lower point code of the pair,
+// so both directions of the signalling land on the same circuit.
+type Key struct {
+	OPC uint32 `json:"opc"`
+	DPC uint32 `json:"dpc"`
+	CIC uint16 `json:"cic"`
+}
+
+// Circuit holds the tracked state of one circuit
+type Circuit struct {
+	CIC                        uint16    `json:"cic"`
+	State                      string    `json:"state"`
+	CallState                  string    `json:"call_state"`
+	LocallyBlockedMaintenance  bool      `json:"locally_blocked_maintenance,omitempty"`
+	RemotelyBlockedMaintenance bool      `json:"remotely_blocked_maintenance,omitempty"`
+	LocallyBlockedHardware     bool      `json:"locally_blocked_hardware,omitempty"`
+	RemotelyBlockedHardware    bool      `json:"remotely_blocked_hardware,omitempty"`
+	Unequipped                 bool      `json:"unequipped,omitempty"`
+	LastMessage                string    `json:"last_message"`
+	LastUpdate                 time.Time `json:"last_update"`
+
+	maintenanceBlocked [2]bool
+	hardwareBlocked    [2]bool
+	pending            map[uint8]pendingRequest // keyed by the expected acknowledgement
+}
+
+// Trunk groups the circuits between two point codes
+type Trunk struct {
+	OPC      uint32         `json:"opc"`
+	DPC      uint32         `json:"dpc"`
+	Summary  map[string]int `json:"summary"`
+	Circuits []Circuit      `json:"circuits"`
+}
+
+// Inconsistency records a message that contradicts the tracked circuit state
+type Inconsistency struct {
+	Timestamp   time.Time `json:"timestamp"`
+	OPC         uint32    `json:"opc"`
+	DPC         uint32    `json:"dpc"`
+	CIC         uint16    `json:"cic"`
+	Message     string    `json:"message"`
+	Description string    `json:"description"`
+}
+
+// Report is a snapshot of every tracked circuit
+type Report struct {
+	Report          string          `json:"report"`
+	Timestamp       time.Time       `json:"timestamp"`
+	Trunks          []Trunk         `json:"trunks"`
+	Inconsistencies []Inconsistency `json:"inconsistencies,omitempty"`
+}
+
+// Maintenance request waiting for its acknowledgement
+type pendingRequest struct {
+	side        int
+	messageType uint8
+	timestamp   time.Time
+}
+
+// Tracker keeps the state of every (OPC, DPC, CIC) seen in the capture
+type Tracker struct {
+	ackTimeout      time.Duration
+	circuits        map[Key]*Circuit
+	inconsistencies []Inconsistency
+	reported        int // inconsistencies already included in a snapshot
+}
+
+// Acknowledgement expected for each maintenance request
+var expectedAck = map[uint8]uint8{
+	isup.ISUPMessageTypeBLO: isup.ISUPMessageTypeBLA,
+	isup.ISUPMessageTypeUBL: isup.ISUPMessageTypeUBA,
+	isup.ISUPMessageTypeGRS: isup.ISUPMessageTypeGRA,
+	isup.ISUPMessageTypeCGB: isup.ISUPMessageTypeCGBA,
+	isup.ISUPMessageTypeCGU: isup.ISUPMessageTypeCGUA,
+}
+
+// NewTracker creates a circuit tracker. A maintenance request not acknowledged
+// within ackTimeout is reported as an inconsistency.
+func NewTracker(ackTimeout time.Duration) *Tracker {
+	return &Tracker{
+		ackTimeout: ackTimeout,
+		circuits:   make(map[Key]*Circuit),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+
+	side := sideLocal
+	if opc > dpc {
+		opc, dpc = dpc, opc
+		side = sideRemote
+	}
+
+	// Circuit group messages act on every circuit in their range
+	if msg.CircuitGroup != nil {
+		for _, cs := range msg.CircuitGroup.Circuits {
+			key := Key{OPC: opc, DPC: dpc, CIC: cs.CIC}
+			t.apply(ts, t.circuit(key), key, side, msg, &cs)
+		}
+		return
+	}
+
+	key := Key{OPC: opc, DPC: dpc, CIC: msg.CIC}
+	t.apply(ts, t.circuit(key), key, side, msg, nil)
+}
+
+// Snapshot returns the current state of every circuit and the inconsistencies
+// found since the previous snapshot, including overdue acknowledgements
+func (t *Tracker) Snapshot(ts time.Time) *Report {
+	t.expirePending(ts, false)
+	report := t.report(ReportSnapshot, ts)
+	report.Inconsistencies = t.inconsistencies[t.reported:]
+	t.reported = len(t.inconsistencies)
+	return report
+}
+
+// Finish returns the final state map, reporting every acknowledgement still missing
+func (t *Tracker) Finish(ts time.Time) *Report {
+	t.expirePending(ts, true)
+	report := t.report(ReportFinal, ts)
+	report.Inconsistencies = t.inconsistencies
+	return report
+}
+
+func (t *Tracker) circuit(key Key) *Circuit {
+	c, exists := t.circuits[key]
+	if !exists {
+		c = &Circuit{
+			CIC:       key.CIC,
+			CallState: StateIdle,
+			pending:   make(map[uint8]pendingRequest),
+		}
+		t.circuits[key] = c
+	}
+	return c
+}
+
+func (t *Tracker) apply(ts time.Time, c *Circuit, key Key, side int, msg *isup.ISUPMessage, cs *isup.CircuitStatus) {
+	other := 1 - side
+	flagged := cs == nil || cs.Status == nil || *cs.Status == 1
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeIAM:
+		if c.maintenanceBlocked[other] || c.hardwareBlocked[other] {
+			t.flag(ts, key, msg, "IAM on a remotely blocked circuit")
+		}
+		if c.Unequipped {
+			t.flag(ts, key, msg, "IAM on an unequipped circuit")
+		}
+		if c.CallState != StateIdle {
+			t.flag(ts, key, msg, "IAM on a busy circuit (dual seizure)")
+		}
+		c.CallState = StateBusyOutgoing
+		if side == sideRemote {
+			c.CallState = StateBusyIncoming
+		}
+	case isup.ISUPMessageTypeRLC:
+		c.CallState = StateIdle
+	case isup.ISUPMessageTypeBLO:
+		c.maintenanceBlocked[side] = true
+	case isup.ISUPMessageTypeUBL:
+		c.maintenanceBlocked[side] = false
+	case isup.ISUPMessageTypeRSC:
+		// The sender lost its circuit state, so its blocking no longer applies
+		c.CallState = StateIdle
+		c.maintenanceBlocked[side] = false
+	case isup.ISUPMessageTypeGRS:
+		c.CallState = StateIdle
+		c.maintenanceBlocked[side] = false
+	case isup.ISUPMessageTypeGRA:
+		c.maintenanceBlocked[side] = cs != nil && cs.Status != nil && *cs.Status == 1
+	case isup.ISUPMessageTypeCGB:
+		if flagged {
+			if isHardwareOriented(msg) {
+				c.hardwareBlocked[side] = true
+			} else {
+				c.maintenanceBlocked[side] = true
+			}
+		}
+	case isup.ISUPMessageTypeCGU:
+		if flagged {
+			if isHardwareOriented(msg) {
+				c.hardwareBlocked[side] = false
+			} else {
+				c.maintenanceBlocked[side] = false
+			}
+		}
+	case isup.ISUPMessageTypeUCIC:
+		c.Unequipped = true
+	case isup.ISUPMessageTypeCQR:
+		if cs != nil && cs.State != nil {
+			applyCircuitState(c, side, cs.State)
+		}
+	}
+
+	// Maintenance request/acknowledgement pairing
+	if ack, isRequest := expectedAck[msg.MessageType]; isRequest && flagged {
+		c.pending[ack] = pendingRequest{side: side, messageType: msg.MessageType, timestamp: ts}
+	}
+	if req, isAck := c.pending[msg.MessageType]; isAck && req.side == other {
+		if ts.Sub(req.timestamp) > t.ackTimeout {
+			t.flag(ts, key, msg, fmt.Sprintf("%s acknowledged after %s",
+				isup.GetISUPMessageTypeName(req.messageType), ts.Sub(req.timestamp)))
+		}
+		delete(c.pending, msg.MessageType)
+	} else if msg.MessageType == isup.ISUPMessageTypeBLA || msg.MessageType == isup.ISUPMessageTypeUBA {
+		t.flag(ts, key, msg, fmt.Sprintf("%s without a matching request", isup.GetISUPMessageTypeName(msg.MessageType)))
+	}
+
+	c.LastMessage = msg.MessageName
+	c.LastUpdate = ts
+}
+
+// Apply the state reported in a CQR by the sending side
+func applyCircuitState(c *Circuit, side int, state *isup.CircuitState) {
+	other := 1 - side
+
+	if state.CallProcessing == 0 {
+		c.Unequipped = state.MaintenanceBlocking == 0x03
+		return
+	}
+
+	// Blocking states are local/remote from the sender's view
+	c.maintenanceBlocked[side] = state.MaintenanceBlocking&0x01 != 0
+	c.maintenanceBlocked[other] = state.MaintenanceBlocking&0x02 != 0
+	c.hardwareBlocked[side] = state.HardwareBlocking&0x01 != 0
+	c.hardwareBlocked[other] = state.HardwareBlocking&0x02 != 0
+
+	switch state.CallProcessing {
+	case 0x01: // incoming busy at the sender
+		c.CallState = StateBusyIncoming
+		if side == sideRemote {
+			c.CallState = StateBusyOutgoing
+		}
+	case 0x02: // outgoing busy at the sender
+		c.CallState = StateBusyOutgoing
+		if side == sideRemote {
+			c.CallState = StateBusyIncoming
+		}
+	case 0x03:
+		c.CallState = StateIdle
+	}
+}
+
+func isHardwareOriented(msg *isup.ISUPMessage) bool {
+	return msg.CircuitGroup.SupervisionType != nil && msg.CircuitGroup.SupervisionType.Num == 0x01
+}
+
+// Report every maintenance request whose acknowledgement is overdue, or all of them at the end of the capture
+func (t *Tracker) expirePending(ts time.Time, all bool) {
+	for key, c := range t.circuits {
+		for ack, req := range c.pending {
+			if !all && ts.Sub(req.timestamp) < t.ackTimeout {
+				continue
+			}
+			t.inconsistencies = append(t.inconsistencies, Inconsistency{
+				Timestamp: req.timestamp,
+				OPC:       key.OPC,
+				DPC:       key.DPC,
+				CIC:       key.CIC,
+				Message:   isup.GetISUPMessageTypeName(req.messageType),
+				Description: fmt.Sprintf("%s without %s",
+					isup.GetISUPMessageTypeName(req.messageType), isup.GetISUPMessageTypeName(ack)),
+			})
+			delete(c.pending, ack)
+		}
+	}
+}
+
+func (t *Tracker) flag(ts time.Time, key Key, msg *isup.ISUPMessage, description string) {
+	t.inconsistencies = append(t.inconsistencies, Inconsistency{
+		Timestamp:   ts,
+		OPC:         key.OPC,
+		DPC:         key.DPC,
+		CIC:         key.CIC,
+		Message:     msg.MessageName,
+		Description: description,
+	})
+}
+
+func (t *Tracker) report(kind string, ts time.Time) *Report {
+	trunks := make(map[[2]uint32]*Trunk)
+	for key, c := range t.circuits {
+		tk := [2]uint32{key.OPC, key.DPC}
+		trunk, exists := trunks[tk]
+		if !exists {
+			trunk = &Trunk{OPC: key.OPC, DPC: key.DPC, Summary: make(map[string]int)}
+			trunks[tk] = trunk
+		}
+		snap := *c
+		snap.refresh()
+		trunk.Summary[snap.State]++
+		trunk.Circuits = append(trunk.Circuits, snap)
+	}
+
+	report := &Report{Report: kind, Timestamp: ts}
+	for _, trunk := range trunks {
+		sort.Slice(trunk.Circuits, func(i, j int) bool { return trunk.Circuits[i].CIC < trunk.Circuits[j].CIC })
+		report.Trunks = append(report.Trunks, *trunk)
+	}
+	sort.Slice(report.Trunks, func(i, j int) bool {
+		if report.Trunks[i].OPC != report.Trunks[j].OPC {
+			return report.Trunks[i].OPC < report.Trunks[j].OPC
+		}
+		return report.Trunks[i].DPC < report.Trunks[j].DPC
+	})
+
+	return report
+}
+
+// Fill the exported flags and the overall state, blocking and unequipped taking precedence over the call state
+func (c *Circuit) refresh() {
+	c.LocallyBlockedMaintenance = c.maintenanceBlocked[sideLocal]
+	c.RemotelyBlockedMaintenance = c.maintenanceBlocked[sideRemote]
+	c.LocallyBlockedHardware = c.hardwareBlocked[sideLocal]
+	c.RemotelyBlockedHardware = c.hardwareBlocked[sideRemote]
+
+	switch {
+	case c.Unequipped:
+		c.State = StateUnequipped
+	case c.LocallyBlockedHardware:
+		c.State = StateLocallyBlockedHardware
+	case c.RemotelyBlockedHardware:
+		c.State = StateRemotelyBlockedHardware
+	case c.LocallyBlockedMaintenance:
+		c.State = StateLocallyBlockedMaintenance
+	case c.RemotelyBlockedMaintenance:
+		c.State = StateRemotelyBlockedMaintenance
+	default:
+		c.State = c.CallState
+	}
+}
@@ -0,0 +1,142 @@
+package confusion
+
+import (
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportConfusion = "confusion"
+
+// Trigger is the message a CFN or UCIC was sent in response to
+type Trigger struct {
+	Timestamp    time.Time `json:"timestamp"`
+	MessageType  uint8     `json:"message_type"`
+	MessageName  string    `json:"message_name"`
+	DelaySeconds float64   `json:"delay_seconds"`
+}
+
+// Event is one CFN or UCIC linked to the message that triggered it
+type Event struct {
+	Timestamp       time.Time `json:"timestamp"`
+	OPC             uint32    `json:"opc"` // Exchange rejecting the message
+	DPC             uint32    `json:"dpc"`
+	CIC             uint16    `json:"cic"`
+	Message         string    `json:"message"`
+	CauseValue      uint8     `json:"cause_value,omitempty"`
+	CauseName       string    `json:"cause_name,omitempty"`
+	DiagnosticNames []string  `json:"diagnostic_names,omitempty"`
+	Trigger         *Trigger  `json:"trigger,omitempty"`
+}
+
+// Report lists the CFN and UCIC seen in the capture
+type Report struct {
+	Report string  `json:"report"`
+	Events []Event `json:"events"`
+}
+
+// Messages sent in one direction on a circuit
+type directionKey struct {
+	opc uint32
+	dpc uint32
+	cic uint16
+}
+
+// Last message of a given type sent in one direction on a circuit
+type sentKey struct {
+	directionKey
+	messageType uint8
+}
+
+type sentMessage struct {
+	ts          time.Time
+	messageType uint8
+	messageName string
+}
+
+// Tracker remembers the last messages sent on each circuit to explain CFN and UCIC
+type Tracker struct {
+	last   map[directionKey]sentMessage
+	byType map[sentKey]sentMessage
+	events []Event
+}
+
+// NewTracker creates a confusion tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		last:   make(map[directionKey]sentMessage),
+		byType: make(map[sentKey]sentMessage),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeCFN, isup.ISUPMessageTypeUCIC:
+		event := Event{
+			Timestamp: ts,
+			OPC:       opc,
+			DPC:       dpc,
+			CIC:       msg.CIC,
+			Message:   msg.MessageName,
+		}
+		var cause *isup.CauseIndicators
+		if msg.CFN != nil {
+			cause = msg.CFN.Cause
+		}
+		if cause != nil {
+			event.CauseValue = cause.CauseValue
+			event.CauseName = cause.CauseName
+			event.DiagnosticNames = cause.DiagnosticNames
+		}
+
+		// The rejected message came the other way on the same circuit
+		reverse := directionKey{opc: dpc, dpc: opc, cic: msg.CIC}
+		sent, exists := t.last[reverse]
+		if cause != nil && isMessageTypeCause(cause.CauseValue) {
+			// The diagnostic names the message type: take the last one of that type
+			diagnostic, err := hex.DecodeString(cause.Diagnostic)
+			if err == nil && len(diagnostic) >= 1 {
+				if s, found := t.byType[sentKey{directionKey: reverse, messageType: diagnostic[0]}]; found {
+					sent, exists = s, true
+				}
+			}
+		}
+		if exists {
+			event.Trigger = &Trigger{
+				Timestamp:    sent.ts,
+				MessageType:  sent.messageType,
+				MessageName:  sent.messageName,
+				DelaySeconds: ts.Sub(sent.ts).Seconds(),
+			}
+		}
+		t.events = append(t.events, event)
+	}
+
+	key := directionKey{opc: opc, dpc: dpc, cic: msg.CIC}
+	sent := sentMessage{ts: ts, messageType: msg.MessageType, messageName: msg.MessageName}
+	t.last[key] = sent
+	t.byType[sentKey{directionKey: key, messageType: msg.MessageType}] = sent
+}
+
+// Finish returns the CFN and UCIC events
+func (t *Tracker) Finish() *Report {
+	report := &Report{Report: ReportConfusion, Events: t.events}
+	sort.SliceStable(report.Events, func(i, j int) bool { return report.Events[i].Timestamp.Before(report.Events[j].Timestamp) })
+	return report
+}
+
+func isMessageTypeCause(cause uint8) bool {
+	switch cause {
+	case isup.CauseMessageTypeNonExistent, isup.CauseMessageNotCompatible, isup.CauseMessageNotCompatibleCallState:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package continuity
+
+import (
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportContinuity = "continuity"
+
+// Why a call never got its COT
+const (
+	MissingReleased   = "released before COT"
+	MissingEndOfTrace = "no COT before end of capture"
+)
+
+// Trunk holds the continuity check counters between two point codes
+type Trunk struct {
+	OPC           uint32  `json:"opc"` // Lower point code of the pair
+	DPC           uint32  `json:"dpc"`
+	Required      int     `json:"required"` // IAMs requiring a COT
+	Passed        int     `json:"passed"`
+	Failed        int     `json:"failed"`
+	Missing       int     `json:"missing"`
+	FailureRate   float64 `json:"failure_rate"` // Failed over checks with a result
+	Retests       int     `json:"retests"`      // CCR
+	Loopbacks     int     `json:"loopbacks"`    // LPA
+	RetestsPassed int     `json:"retests_passed"`
+	RetestsFailed int     `json:"retests_failed"`
+}
+
+// MissingCOT is a call held waiting for a COT that never came
+type MissingCOT struct {
+	OPC           uint32     `json:"opc"` // IAM sender
+	DPC           uint32     `json:"dpc"`
+	CIC           uint16     `json:"cic"`
+	IAM           time.Time  `json:"iam"`
+	Released      *time.Time `json:"released,omitempty"`
+	WaitedSeconds float64    `json:"waited_seconds"`
+	Check         string     `json:"check"`
+	Reason        string     `json:"reason"`
+}
+
+// Report is the continuity check outcome per trunk
+type Report struct {
+	Report     string       `json:"report"`
+	Trunks     []Trunk      `json:"trunks"`
+	MissingCOT []MissingCOT `json:"missing_cot,omitempty"`
+}
+
+type circuitKey struct {
+	pcA uint32
+	pcB uint32
+	cic uint16
+}
+
+// Continuity check in progress on a circuit
+type pendingCheck struct {
+	opc   uint32
+	dpc   uint32
+	iam   time.Time
+	check string
+}
+
+// Tracker links IAM, COT, CCR and LPA per circuit
+type Tracker struct {
+	trunks    map[[2]uint32]*Trunk
+	pending   map[circuitKey]*pendingCheck
+	retesting map[circuitKey]bool
+	missing   []MissingCOT
+}
+
+// NewTracker creates a continuity tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		trunks:    make(map[[2]uint32]*Trunk),
+		pending:   make(map[circuitKey]*pendingCheck),
+		retesting: make(map[circuitKey]bool),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+	pcA, pcB := opc, dpc
+	if pcA > pcB {
+		pcA, pcB = pcB, pcA
+	}
+	key := circuitKey{pcA: pcA, pcB: pcB, cic: msg.CIC}
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeIAM:
+		// A new call ends any retest left without a COT
+		delete(t.pending, key)
+		delete(t.retesting, key)
+		if msg.IAM == nil || msg.IAM.NatureOfConnection == nil {
+			return
+		}
+		// A check on this circuit or on a previous one is followed by a COT
+		noc := msg.IAM.NatureOfConnection
+		if noc.ContinuityCheck == 0x01 || noc.ContinuityCheck == 0x02 {
+			t.pending[key] = &pendingCheck{opc: opc, dpc: dpc, iam: ts, check: noc.ContinuityCheckName}
+			t.trunk(pcA, pcB).Required++
+		}
+	case isup.ISUPMessageTypeCOT:
+		passed := msg.COT != nil && msg.COT.Continuity == 0x01
+		trunk := t.trunk(pcA, pcB)
+		if t.retesting[key] {
+			// Outcome of a CCR retest
+			delete(t.retesting, key)
+			if passed {
+				trunk.RetestsPassed++
+			} else {
+				trunk.RetestsFailed++
+			}
+			return
+		}
+		if _, exists := t.pending[key]; exists {
+			delete(t.pending, key)
+			if passed {
+				trunk.Passed++
+			} else {
+				trunk.Failed++
+			}
+		}
+	case isup.ISUPMessageTypeCCR:
+		t.retesting[key] = true
+		t.trunk(pcA, pcB).Retests++
+	case isup.ISUPMessageTypeLPA:
+		t.trunk(pcA, pcB).Loopbacks++
+	case isup.ISUPMessageTypeREL, isup.ISUPMessageTypeRLC:
+		// A successful retest is released without a COT
+		delete(t.retesting, key)
+		if p, exists := t.pending[key]; exists {
+			t.addMissing(key, p, ts, true, MissingReleased)
+			delete(t.pending, key)
+		}
+	}
+}
+
+// Finish returns the continuity report, counting the checks still waiting for a COT
+func (t *Tracker) Finish(ts time.Time) *Report {
+	for key, p := range t.pending {
+		t.addMissing(key, p, ts, false, MissingEndOfTrace)
+		delete(t.pending, key)
+	}
+
+	report := &Report{Report: ReportContinuity, MissingCOT: t.missing}
+	for _, trunk := range t.trunks {
+		if checked := trunk.Passed + trunk.Failed; checked > 0 {
+			trunk.FailureRate = float64(trunk.Failed) / float64(checked)
+		}
+		report.Trunks = append(report.Trunks, *trunk)
+	}
+	sort.Slice(report.Trunks, func(i, j int) bool {
+		if report.Trunks[i].OPC != report.Trunks[j].OPC {
+			return report.Trunks[i].OPC < report.Trunks[j].OPC
+		}
+		return report.Trunks[i].DPC < report.Trunks[j].DPC
+	})
+	sort.Slice(report.MissingCOT, func(i, j int) bool { return report.MissingCOT[i].IAM.Before(report.MissingCOT[j].IAM) })
+
+	return report
+}
+
+func (t *Tracker) trunk(pcA, pcB uint32) *Trunk {
+	trunk, exists := t.trunks[[2]uint32{pcA, pcB}]
+	if !exists {
+		trunk = &Trunk{OPC: pcA, DPC: pcB}
+		t.trunks[[2]uint32{pcA, pcB}] = trunk
+	}
+	return trunk
+}
+
+// Record a check whose COT never came, waiting from the IAM until end
+func (t *Tracker) addMissing(key circuitKey, p *pendingCheck, end time.Time, released bool, reason string) {
+	missing := MissingCOT{
+		OPC:           p.opc,
+		DPC:           p.dpc,
+		CIC:           key.cic,
+		IAM:           p.iam,
+		WaitedSeconds: end.Sub(p.iam).Seconds(),
+		Check:         p.check,
+		Reason:        reason,
+	}
+	if released {
+		missing.Released = &end
+	}
+	t.missing = append(t.missing, missing)
+	t.trunk(key.pcA, key.pcB).Missing++
+}
@@ -0,0 +1,167 @@
+package isup
+
+import (
+	"encoding/hex"
+)
+
+// Q.931 information element identifiers carried in Access Transport (ITU-T Q.931 4.5)
+const (
+	IEBearerCapability       = 0x04
+	IECause                  = 0x08
+	IEProgressIndicator      = 0x1E
+	IECallingPartySubaddress = 0x6D
+	IECalledPartySubaddress  = 0x71
+	IELowLayerCompatibility  = 0x7C
+	IEHighLayerCompatibility = 0x7D
+)
+
+// Subaddress types (ITU-T Q.931 4.5.9 and 4.5.11)
+const (
+	SubaddressNSAP          = 0x00
+	SubaddressUserSpecified = 0x02
+)
+
+// AFI of an NSAP subaddress made of IA5 characters (X.213)
+const nsapAFIIA5 = 0x50
+
+// GetInformationElementName returns the Q.931 name of an information element identifier
+func GetInformationElementName(id uint8) string {
+	if id&0x80 != 0 {
+		// Single octet information elements are identified by their high nibble
+		id &= 0xF0
+	}
+	if name, exists := informationElementValues[id]; exists {
+		return name
+	}
+	return "Unknown information element"
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse an Access Transport parameter (ITU-T Q.763 3.3) into its Q.931 information elements
+func parseAccessTransport(data []byte) []*InformationElement {
+	var elements []*InformationElement
+
+	Len := len(data)
+	offset := 0
+	for offset < Len {
+		id := data[offset]
+		offset++
+
+		// Single octet information elements have no length
+		if id&0x80 != 0 {
+			elements = append(elements, &InformationElement{
+				ID:   id,
+				Name: GetInformationElementName(id),
+			})
+			continue
+		}
+
+		if offset >= Len {
+			break
+		}
+		l := int(data[offset])
+		offset++
+		if offset+l > Len {
+			break
+		}
+		contents := data[offset : offset+l]
+		offset += l
+
+		elements = append(elements, parseInformationElement(id, contents))
+	}
+
+	return elements
+}
+
+// Decode the contents of a variable length Q.931 information element
+func parseInformationElement(id uint8, contents []byte) *InformationElement {
+	ie := &InformationElement{
+		ID:   id,
+		Name: GetInformationElementName(id),
+		Data: hex.EncodeToString(contents),
+	}
+
+	switch id {
+	case IEBearerCapability:
+		ie.BearerCapability = parseUserServiceInformation(contents)
+	case IELowLayerCompatibility:
+		// Skip the negotiation indicator octet 3a, the rest is coded as a bearer capability
+		if len(contents) >= 2 && contents[0]&0x80 == 0 {
+			llc := append([]byte{contents[0]}, contents[2:]...)
+			ie.LowLayerCompatibility = parseUserServiceInformation(llc)
+		} else {
+			ie.LowLayerCompatibility = parseUserServiceInformation(contents)
+		}
+	case IEHighLayerCompatibility:
+		ie.HighLayerCompatibility = parseHighLayerCompatibility(contents)
+	case IECalledPartySubaddress, IECallingPartySubaddress:
+		ie.Subaddress = parseSubaddress(contents)
+	case IEProgressIndicator:
+		ie.ProgressIndicator = parseProgressIndicator(contents)
+	}
+
+	return ie
+}
+
+// Parse a High Layer Compatibility information element (ITU-T Q.931 4.5.17)
+func parseHighLayerCompatibility(data []byte) *HighLayerCompatibility {
+	if len(data) < 2 {
+		return nil
+	}
+
+	hlc := &HighLayerCompatibility{
+		CodingStandard:  CodingStandardValues[(data[0]>>5)&0x03],
+		Interpretation:  (data[0] >> 2) & 0x07,
+		Characteristics: data[1] & 0x7F,
+	}
+	hlc.CharacteristicsName = highLayerCharacteristicsValues[hlc.Characteristics]
+
+	// Extended high layer characteristics identification
+	if len(data) >= 3 && data[1]&0x80 == 0 {
+		extended := data[2] & 0x7F
+		hlc.Extended = &extended
+	}
+
+	return hlc
+}
+
+// Parse a Called or Calling Party Subaddress information element (ITU-T Q.931 4.5.9 and 4.5.11)
+func parseSubaddress(data []byte) *Subaddress {
+	if len(data) < 1 {
+		return nil
+	}
+
+	subaddress := &Subaddress{
+		Type: (data[0] >> 4) & 0x07,
+		Odd:  (data[0]>>3)&0x01 == 1,
+	}
+	subaddress.TypeName = subaddressTypeValues[subaddress.Type]
+
+	info := data[1:]
+	subaddress.Data = hex.EncodeToString(info)
+	if subaddress.Type == SubaddressNSAP && len(info) >= 1 && info[0] == nsapAFIIA5 {
+		subaddress.Address = string(info[1:])
+	}
+
+	return subaddress
+}
+
+// Parse a Progress Indicator information element (ITU-T Q.931 4.5.23)
+func parseProgressIndicator(data []byte) *ProgressIndicator {
+	if len(data) < 2 {
+		return nil
+	}
+
+	progress := &ProgressIndicator{
+		CodingStandard: CodingStandardValues[(data[0]>>5)&0x03],
+		Location:       data[0] & 0x0F,
+		Description:    data[1] & 0x7F,
+	}
+	progress.LocationName = causeLocationValues[progress.Location]
+	progress.DescriptionName = progressDescriptionValues[progress.Description]
+
+	return progress
+}
@@ -0,0 +1,110 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseACM decodes an Address Complete message according to ITU-T Q.763
+func ParseACM(data []byte) (*ACMParameters, error) {
+	Len := len(data)
+	if Len < 2 {
+		return nil, fmt.Errorf("missing Backward Call Indicators")
+	}
+	acm := &ACMParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	acm.add(ISUPBackwardCallIndicators, data[:2])
+
+	/**
+	** Optional parameters (after the two octets of Backward Call Indicators)
+	**/
+	forEachOptionalParameter(data, 2, func(code uint8, val []byte) {
+		param := acm.add(code, val)
+		switch code {
+		case ISUPUserToUserIndicators:
+			acm.UserToUserIndicators = parseUserToUserIndicators(val)
+			acm.decoded(param, "acm.user_to_user_indicators", acm.UserToUserIndicators != nil)
+		case ISUPUserToUserInformation:
+			acm.UserToUserInformation = parseUserToUserInformation(val)
+			acm.decoded(param, "acm.user_to_user_information", acm.UserToUserInformation != nil)
+		case ISUPTransmissionMediumUsed:
+			if len(val) >= 1 {
+				acm.TransmissionMediumUsed = parseTransmissionMedium(val[0])
+			}
+			acm.decoded(param, "acm.transmission_medium_used", len(val) >= 1)
+		case ISUPAccessTransport:
+			acm.AccessTransport = parseAccessTransport(val)
+			acm.decoded(param, "acm.access_transport", acm.AccessTransport != nil)
+		case ISUPApplicationTransportParameter:
+			app := parseApplicationTransport(val)
+			if app != nil {
+				acm.ApplicationTransport = append(acm.ApplicationTransport, app)
+			}
+			acm.decoded(param, elementPath("acm.application_transport", len(acm.ApplicationTransport)), app != nil)
+		case ISUPCallDiversionInformation:
+			acm.CallDiversionInformation = parseCallDiversionInformation(val)
+			acm.decoded(param, "acm.call_diversion_information", acm.CallDiversionInformation != nil)
+		case ISUPRedirectionNumber:
+			acm.RedirectionNumber = parseNumberInfoCalled(val)
+			acm.decoded(param, "acm.redirection_number", acm.RedirectionNumber != nil)
+		case ISUPRedirectionNumberRestriction:
+			acm.RedirectionNumberRestriction = parseRedirectionNumberRestriction(val)
+			acm.decoded(param, "acm.redirection_number_restriction", acm.RedirectionNumberRestriction != nil)
+		case ISUPRedirectStatus:
+			acm.RedirectStatus = parseRedirectStatus(val)
+			acm.decoded(param, "acm.redirect_status", acm.RedirectStatus != nil)
+		case ISUPMessageCompatibilityInformation:
+			acm.MessageCompatibility = parseMessageCompatibilityInformation(val)
+			acm.decoded(param, "acm.message_compatibility", acm.MessageCompatibility != nil)
+		case ISUPParameterCompatibilityInformation:
+			acm.ParameterCompatibility = parseParameterCompatibilityInformation(val)
+			acm.decoded(param, "acm.parameter_compatibility", acm.ParameterCompatibility != nil)
+		}
+	})
+
+	return acm, nil
+}
+
+// ParseANM decodes an Answer message according to ITU-T Q.763
+func ParseANM(data []byte) (*ANMParameters, error) {
+	anm := &ANMParameters{}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := anm.add(code, val)
+		switch code {
+		case ISUPUserToUserIndicators:
+			anm.UserToUserIndicators = parseUserToUserIndicators(val)
+			anm.decoded(param, "anm.user_to_user_indicators", anm.UserToUserIndicators != nil)
+		case ISUPUserToUserInformation:
+			anm.UserToUserInformation = parseUserToUserInformation(val)
+			anm.decoded(param, "anm.user_to_user_information", anm.UserToUserInformation != nil)
+		case ISUPTransmissionMediumUsed:
+			if len(val) >= 1 {
+				anm.TransmissionMediumUsed = parseTransmissionMedium(val[0])
+			}
+			anm.decoded(param, "anm.transmission_medium_used", len(val) >= 1)
+		case ISUPAccessTransport:
+			anm.AccessTransport = parseAccessTransport(val)
+			anm.decoded(param, "anm.access_transport", anm.AccessTransport != nil)
+		case ISUPApplicationTransportParameter:
+			app := parseApplicationTransport(val)
+			if app != nil {
+				anm.ApplicationTransport = append(anm.ApplicationTransport, app)
+			}
+			anm.decoded(param, elementPath("anm.application_transport", len(anm.ApplicationTransport)), app != nil)
+		case ISUPMessageCompatibilityInformation:
+			anm.MessageCompatibility = parseMessageCompatibilityInformation(val)
+			anm.decoded(param, "anm.message_compatibility", anm.MessageCompatibility != nil)
+		case ISUPParameterCompatibilityInformation:
+			anm.ParameterCompatibility = parseParameterCompatibilityInformation(val)
+			anm.decoded(param, "anm.parameter_compatibility", anm.ParameterCompatibility != nil)
+		}
+	})
+
+	return anm, nil
+}
@@ -0,0 +1,227 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Application context identifier of the Bearer Association Transport ASE
+const APMContextBAT = 5
+
+// BAT information element identifiers (ITU-T Q.765.5)
+const (
+	BATActionIndicator = 0x01
+	BATBNCID           = 0x02
+	BATCodecList       = 0x04
+	BATSingleCodec     = 0x05
+)
+
+// Codec organization identifiers (ITU-T Q.765.5)
+const (
+	CodecOrganizationITU  = 0x01
+	CodecOrganizationETSI = 0x02
+)
+
+// ParseAPT decodes an Application Transport message according to ITU-T Q.763
+func ParseAPT(data []byte) (*APTParameters, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("APT too short")
+	}
+	apt := &APTParameters{}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := apt.add(code, val)
+		switch code {
+		case ISUPApplicationTransportParameter:
+			app := parseApplicationTransport(val)
+			if app != nil {
+				apt.ApplicationTransport = append(apt.ApplicationTransport, app)
+			}
+			apt.decoded(param, elementPath("apt.application_transport", len(apt.ApplicationTransport)), app != nil)
+		}
+	})
+
+	return apt, nil
+}
+
+// ParseBAT decodes the BAT ASE information elements of an APM-user information (ITU-T Q.765.5)
+func ParseBAT(data []byte) (*BATInformation, error) {
+	bat := &BATInformation{}
+
+	err := forEachBATElement(data, func(id uint8, compat uint8, content []byte) {
+		switch id {
+		case BATActionIndicator:
+			if len(content) >= 1 {
+				bat.ActionIndicator = &BATAction{
+					Num:  content[0],
+					Name: batActionIndicatorValues[content[0]],
+				}
+			}
+		case BATBNCID:
+			bat.BNCID = hex.EncodeToString(content)
+		case BATCodecList:
+			// Codec list: a list of Single Codec information elements
+			forEachBATElement(content, func(id uint8, compat uint8, codec []byte) {
+				if id == BATSingleCodec {
+					if c := parseCodec(codec); c != nil {
+						bat.CodecList = append(bat.CodecList, *c)
+					}
+				}
+			})
+		case BATSingleCodec:
+			bat.SingleCodec = parseCodec(content)
+		default:
+			bat.Others = append(bat.Others, BATElement{
+				Identifier: id,
+				Name:       batIdentifierValues[id],
+				Content:    hex.EncodeToString(content),
+			})
+		}
+	})
+
+	return bat, err
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse an Application Transport Parameter (ITU-T Q.763 3.82)
+func parseApplicationTransport(data []byte) *ApplicationTransport {
+
+	Len := len(data)
+
+	if Len < 3 {
+		return nil
+	}
+
+	app := &ApplicationTransport{}
+	offset := 0
+
+	// Application context identifier, extended to a second octet when bit 8 is 0
+	app.ContextID = uint16(data[offset] & 0x7F)
+	if data[offset]&0x80 == 0 && offset+1 < Len {
+		offset++
+		app.ContextID = app.ContextID<<7 | uint16(data[offset]&0x7F)
+	}
+	app.ContextName = getApplicationContextName(app.ContextID)
+	offset++
+
+	if offset+2 > Len {
+		return app
+	}
+	app.SendNotification = (data[offset] >> 1) & 0x01
+	app.SendNotificationName = sendNotificationValues[app.SendNotification]
+	app.ReleaseCall = data[offset] & 0x01
+	app.ReleaseCallName = releaseCallValues[app.ReleaseCall]
+	offset++
+
+	app.SequenceIndicator = (data[offset] >> 6) & 0x01
+	app.SequenceIndicatorName = sequenceIndicatorValues[app.SequenceIndicator]
+	app.SegmentationIndicator = data[offset] & 0x3F
+	ext := data[offset] & 0x80
+	offset++
+
+	// Segmentation local reference, present when bit 8 of octet 3 is 0
+	if ext == 0 && offset < Len {
+		slr := data[offset] & 0x7F
+		app.SegmentationLocalReference = &slr
+		offset++
+	}
+
+	app.payload = data[offset:]
+	app.Data = hex.EncodeToString(app.payload)
+
+	// An unsegmented APM carries the whole APM-user information
+	if !app.IsSegmented() {
+		app.decodeInformation()
+	}
+
+	return app
+}
+
+// IsSegmented tells whether the APM-user information is split across several messages
+func (app *ApplicationTransport) IsSegmented() bool {
+	return app.SequenceIndicator == 0 || app.SegmentationIndicator != 0
+}
+
+// Interpret the APM-user information of the application contexts we know about
+func (app *ApplicationTransport) decodeInformation() {
+	switch app.ContextID {
+	case APMContextBAT:
+		app.BAT, _ = ParseBAT(app.payload)
+	case APMContextCharging:
+		app.Charging, _ = ParseCharging(app.payload)
+	}
+}
+
+// Walk the information elements of a BAT ASE: identifier, length, compatibility information, contents
+func forEachBATElement(data []byte, fn func(id uint8, compat uint8, content []byte)) error {
+	Len := len(data)
+	offset := 0
+
+	for offset < Len {
+		id := data[offset]
+		offset++
+
+		// Length indicator, extended to a second octet when bit 8 is 0
+		if offset >= Len {
+			return fmt.Errorf("BAT element 0x%02X length missing", id)
+		}
+		l := int(data[offset] & 0x7F)
+		if data[offset]&0x80 == 0 && offset+1 < Len {
+			offset++
+			l = l | int(data[offset]&0x7F)<<7
+		}
+		offset++
+
+		// The length covers the compatibility information and the contents
+		if l < 1 || offset+l > Len {
+			return fmt.Errorf("BAT element 0x%02X truncated", id)
+		}
+		fn(id, data[offset], data[offset+1:offset+l])
+		offset += l
+	}
+
+	return nil
+}
+
+// Parse a codec: organization identifier, codec type and optional configuration data
+func parseCodec(data []byte) *Codec {
+
+	Len := len(data)
+
+	if Len < 2 {
+		return nil
+	}
+
+	codec := &Codec{
+		Organization: data[0],
+		Type:         data[1],
+	}
+	codec.OrganizationName = codecOrganizationValues[codec.Organization]
+	switch codec.Organization {
+	case CodecOrganizationITU:
+		codec.Name = itutCodecValues[codec.Type]
+	case CodecOrganizationETSI:
+		codec.Name = etsiCodecValues[codec.Type]
+	}
+	if codec.Name == "" {
+		codec.Name = fmt.Sprintf("unknown codec 0x%02X", codec.Type)
+	}
+	if Len > 2 {
+		codec.Configuration = hex.EncodeToString(data[2:])
+	}
+
+	return codec
+}
+
+func getApplicationContextName(id uint16) string {
+	if name, exists := applicationContextValues[id]; exists {
+		return name
+	}
+	return "spare"
+}
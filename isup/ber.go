@@ -0,0 +1,170 @@
+package isup
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+** Minimal BER decoder, enough for the ROSE components carried in ISUP
+**/
+
+// BER tag classes
+const (
+	berClassUniversal   = 0
+	berClassApplication = 1
+	berClassContext     = 2
+	berClassPrivate     = 3
+)
+
+// BER universal tags
+const (
+	berTagInteger  = 0x02
+	berTagNull     = 0x05
+	berTagOID      = 0x06
+	berTagSequence = 0x10
+)
+
+// One decoded tag-length-value element
+type berElement struct {
+	Class       uint8
+	Constructed bool
+	Tag         uint32
+	Value       []byte
+	Raw         []byte // Whole element, tag and length included
+}
+
+// Decode the consecutive BER elements found in data
+func parseBER(data []byte) ([]berElement, error) {
+	var elements []berElement
+	offset := 0
+
+	for offset < len(data) {
+		el, n, err := parseBERElement(data[offset:])
+		if err != nil {
+			return elements, err
+		}
+		elements = append(elements, el)
+		offset += n
+	}
+
+	return elements, nil
+}
+
+// Decode one BER element, returning it with the number of octets consumed
+func parseBERElement(data []byte) (berElement, int, error) {
+	Len := len(data)
+	if Len < 2 {
+		return berElement{}, 0, fmt.Errorf("BER element too short (%d bytes)", Len)
+	}
+
+	el := berElement{
+		Class:       data[0] >> 6,
+		Constructed: data[0]&0x20 != 0,
+		Tag:         uint32(data[0] & 0x1F),
+	}
+	offset := 1
+
+	// High tag number form
+	if el.Tag == 0x1F {
+		el.Tag = 0
+		for {
+			if offset >= Len {
+				return berElement{}, 0, fmt.Errorf("BER tag truncated")
+			}
+			b := data[offset]
+			offset++
+			el.Tag = el.Tag<<7 | uint32(b&0x7F)
+			if b&0x80 == 0 {
+				break
+			}
+		}
+	}
+
+	if offset >= Len {
+		return berElement{}, 0, fmt.Errorf("BER length missing")
+	}
+	l := int(data[offset])
+	offset++
+
+	switch {
+	case l == 0x80:
+		// Indefinite length, constructed only: ends with the end-of-contents octets
+		end := offset
+		for end+1 < Len && (data[end] != 0 || data[end+1] != 0) {
+			_, n, err := parseBERElement(data[end:])
+			if err != nil {
+				return berElement{}, 0, err
+			}
+			end += n
+		}
+		if end+1 >= Len {
+			return berElement{}, 0, fmt.Errorf("BER end-of-contents missing")
+		}
+		el.Value = data[offset:end]
+		el.Raw = data[:end+2]
+		return el, end + 2, nil
+	case l > 0x80:
+		// Long form
+		n := l & 0x7F
+		if n > 4 || offset+n > Len {
+			return berElement{}, 0, fmt.Errorf("BER length invalid")
+		}
+		l = 0
+		for i := 0; i < n; i++ {
+			l = l<<8 | int(data[offset+i])
+		}
+		offset += n
+	}
+
+	if offset+l > Len {
+		return berElement{}, 0, fmt.Errorf("BER value truncated (%d of %d bytes)", Len-offset, l)
+	}
+	el.Value = data[offset : offset+l]
+	el.Raw = data[:offset+l]
+
+	return el, offset + l, nil
+}
+
+// Decode a two's complement BER INTEGER
+func berInteger(data []byte) int64 {
+	var v int64
+	for i, b := range data {
+		if i == 0 && b&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(b)
+	}
+	return v
+}
+
+// Decode a BER OBJECT IDENTIFIER into dotted notation
+func berOID(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var arcs []string
+	var v uint64
+	first := true
+	for _, b := range data {
+		v = v<<7 | uint64(b&0x7F)
+		if b&0x80 != 0 {
+			continue
+		}
+		if first {
+			// The first subidentifier packs the first two arcs
+			x := v / 40
+			if x > 2 {
+				x = 2
+			}
+			arcs = append(arcs, fmt.Sprint(x), fmt.Sprint(v-x*40))
+			first = false
+		} else {
+			arcs = append(arcs, fmt.Sprint(v))
+		}
+		v = 0
+	}
+
+	return strings.Join(arcs, ".")
+}
@@ -0,0 +1,115 @@
+package isup
+
+import (
+	"encoding/hex"
+)
+
+// Type of network identification (ITU-T Q.763 3.53)
+const (
+	NetworkIdentificationITU      = 0x00
+	NetworkIdentificationNational = 0x02
+)
+
+// National network identification plans (ANSI T1.113)
+const (
+	ANSICarrierCode3Digits = 0x01
+	ANSICarrierCode4Digits = 0x02
+)
+
+// SelectedCarrier returns the carrier chosen for the call: the ANSI Carrier Identification,
+// otherwise the Transit Network Selection
+func (iam *IAMParameters) SelectedCarrier() *NetworkIdentification {
+	if iam.CarrierIdentification != nil {
+		return iam.CarrierIdentification
+	}
+	return iam.TransitNetworkSelection
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Transit Network Selection parameter (ITU-T Q.763 3.53). The ANSI variant
+// follows the carrier identification code with a circuit code.
+func parseTransitNetworkSelection(data []byte, variant Variant) *NetworkIdentification {
+	tns := parseNetworkIdentification(data, variant)
+	if tns == nil || variant != VariantANSI {
+		return tns
+	}
+
+	// Circuit code after the two octets of carrier identification code digits
+	if len(data) >= 4 {
+		code := (data[3] >> 4) & 0x0F
+		tns.CircuitCode = &code
+		tns.CircuitCodeName = circuitCodeValues[code]
+	}
+
+	return tns
+}
+
+// Parse a Carrier Identification parameter (ANSI T1.113), coded like a Transit Network Selection
+func parseCarrierIdentification(data []byte, variant Variant) *NetworkIdentification {
+	return parseNetworkIdentification(data, variant)
+}
+
+// Parse a Carrier Selection Information parameter (ANSI T1.113)
+func parseCarrierSelectionInformation(data []byte) *CarrierSelection {
+	if len(data) < 1 {
+		return nil
+	}
+
+	return &CarrierSelection{
+		Value: data[0],
+		Name:  carrierSelectionValues[data[0]],
+	}
+}
+
+// Parse an Egress Service parameter (ANSI T1.113), kept as text when printable
+func parseEgressService(data []byte) *EgressService {
+	if len(data) < 1 {
+		return nil
+	}
+
+	egress := &EgressService{Data: hex.EncodeToString(data)}
+	for _, b := range data {
+		if b < 0x20 || b > 0x7E {
+			return egress
+		}
+	}
+	egress.Text = string(data)
+
+	return egress
+}
+
+// Decode the type, plan and digits shared by Transit Network Selection and Carrier Identification
+func parseNetworkIdentification(data []byte, variant Variant) *NetworkIdentification {
+	if len(data) < 1 {
+		return nil
+	}
+
+	id := &NetworkIdentification{
+		Type: (data[0] >> 4) & 0x07,
+		Plan: data[0] & 0x0F,
+	}
+	id.TypeName = networkIdentificationTypeValues[id.Type]
+	if id.Type == NetworkIdentificationNational && variant == VariantANSI {
+		id.PlanName = ansiNetworkIdentificationPlanValues[id.Plan]
+	} else {
+		id.PlanName = networkIdentificationPlanValues[id.Plan]
+	}
+
+	// Network identification digits
+	switch {
+	case len(data) < 2:
+	case variant == VariantANSI:
+		// Carrier identification code in two octets, the fourth digit being a filler for 3-digit codes
+		id.Network = decodeBCDAddress(data[1:min(len(data), 3)], false)
+		if id.Plan == ANSICarrierCode3Digits && len(id.Network) > 3 {
+			id.Network = id.Network[:3]
+		}
+	default:
+		id.Network = decodeBCDAddress(data[1:], (data[0]>>7)&0x01 == 1)
+	}
+
+	return id
+}
@@ -0,0 +1,50 @@
+package isup
+
+import (
+	"encoding/hex"
+)
+
+// Parse a Cause Indicators parameter according to ITU-T Q.763 and Q.850
+func parseCauseIndicators(data []byte) *CauseIndicators {
+
+	Len := len(data)
+
+	if Len < 2 {
+		return nil
+	}
+
+	cause := &CauseIndicators{}
+
+	cs := (data[0] >> 5) & 0x03
+	cause.CodingStandard = CodingStandardValues[cs]
+	cause.Location = data[0] & 0x0F
+	cause.LocationName = causeLocationValues[cause.Location]
+
+	// Octet 1a (recommendation) is present when the extension bit is 0
+	offset := 1
+	if data[0]&0x80 == 0 {
+		offset++
+	}
+	if offset >= Len {
+		return cause
+	}
+
+	cause.CauseValue = data[offset] & 0x7F
+	cause.CauseName = GetCauseName(cause.CauseValue)
+	offset++
+
+	if offset < Len {
+		cause.Diagnostic = hex.EncodeToString(data[offset:])
+		cause.DiagnosticNames = causeDiagnosticNames(cause.CauseValue, data[offset:])
+	}
+
+	return cause
+}
+
+// GetCauseName returns the Q.850 name of a cause value
+func GetCauseName(value uint8) string {
+	if name, exists := causeValues[value]; exists {
+		return name
+	}
+	return "Unknown cause"
+}
@@ -0,0 +1,302 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Application context identifier of the Charging ASE (ETSI ES 201 296)
+const APMContextCharging = 3
+
+// Tariff kinds
+const (
+	TariffKindCurrency = "currency"
+	TariffKindPulse    = "pulse"
+)
+
+// ParseCRG decodes a Charge Information message. The ETSI (ETSI ES 201 296) and ANSI (T1.113)
+// variants carry an optional part. The ITU-T format is a national matter: the national options
+// built on the Q.763 layout carry the same optional part, any other format is kept raw.
+func ParseCRG(data []byte, variant Variant) (*CRGParameters, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("CRG too short")
+	}
+	crg := &CRGParameters{}
+
+	if variant == VariantITU && !isOptionalPart(data, 0) {
+		crg.National = hex.EncodeToString(data)
+		return crg, nil
+	}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := crg.add(code, val)
+		switch code {
+		case ISUPChargedPartyIdentification:
+			crg.ChargedPartyIdentification = hex.EncodeToString(val)
+			crg.decoded(param, "crg.charged_party_identification", true)
+		case ISUPApplicationTransportParameter:
+			app := parseApplicationTransport(val)
+			if app != nil {
+				crg.ApplicationTransport = append(crg.ApplicationTransport, app)
+			}
+			crg.decoded(param, elementPath("crg.application_transport", len(crg.ApplicationTransport)), app != nil)
+		case ISUPChargeNumber:
+			crg.ChargeNumber = parseNumberInfoCharge(val)
+			crg.decoded(param, "crg.charge_number", crg.ChargeNumber != nil)
+		case ISUPOriginatingLineInformation:
+			if len(val) >= 1 {
+				crg.OriginatingLineInformation = &OriginatingLineInformation{Value: val[0]}
+			}
+			crg.decoded(param, "crg.originating_line_information", len(val) >= 1)
+		case ISUPJurisdiction:
+			j := parseJurisdictionDigits(val)
+			crg.Jurisdiction = &j
+			crg.decoded(param, "crg.jurisdiction", true)
+		}
+	})
+
+	return crg, nil
+}
+
+// ParseCharging decodes the ChargingMessageType of the Charging ASE (ETSI ES 201 296)
+func ParseCharging(data []byte) (*ChargingInformation, error) {
+	elements, err := parseBER(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 || elements[0].Class != berClassContext {
+		return nil, fmt.Errorf("charging message type missing")
+	}
+
+	el := elements[0]
+	info := &ChargingInformation{
+		MessageType:     uint8(el.Tag),
+		MessageTypeName: chargingMessageTypes[uint8(el.Tag)],
+	}
+	fields, err := parseBER(el.Value)
+	if err != nil {
+		return info, err
+	}
+
+	switch el.Tag {
+	case 0: // Charging tariff information
+		for _, f := range contextFields(fields) {
+			switch f.Tag {
+			case 0:
+				info.ControlIndicators = berBitNames(f.Value, chargingControlIndicatorNames)
+			case 1:
+				info.parseChargingTariff(f.Value)
+			case 2:
+				info.ReferenceIdentification = hex.EncodeToString(f.Value)
+			case 5:
+				info.Currency = chargingCurrency(f.Value)
+			}
+		}
+	case 1: // Add-on charging information
+		for _, f := range contextFields(fields) {
+			switch f.Tag {
+			case 0:
+				info.ControlIndicators = berBitNames(f.Value, chargingControlIndicatorNames)
+			case 1:
+				info.AddOnCharge = parseAddOnCharge(f.Value)
+			case 2:
+				info.ReferenceIdentification = hex.EncodeToString(f.Value)
+			case 5:
+				info.Currency = chargingCurrency(f.Value)
+			}
+		}
+	case 2: // Charging acknowledgement information
+		for _, f := range contextFields(fields) {
+			switch f.Tag {
+			case 0:
+				info.AcknowledgementIndicators = berBitNames(f.Value, chargingAcknowledgementIndicatorNames)
+			case 1:
+				info.ReferenceIdentification = hex.EncodeToString(f.Value)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Decode the chargingTariff choice: tariff in currency or in pulses, possibly with a tariff switch
+func (info *ChargingInformation) parseChargingTariff(data []byte) {
+	choice, err := parseBER(data)
+	if err != nil || len(choice) == 0 {
+		return
+	}
+	kind := TariffKindCurrency
+	if choice[0].Tag == 1 {
+		kind = TariffKindPulse
+	}
+
+	parts, _ := parseBER(choice[0].Value)
+	for _, p := range contextFields(parts) {
+		switch p.Tag {
+		case 0: // Current tariff
+			info.CurrentTariff = parseTariff(kind, p.Value)
+		case 1: // Tariff switch
+			sw, _ := parseBER(p.Value)
+			for _, s := range contextFields(sw) {
+				switch s.Tag {
+				case 0: // Next tariff
+					info.NextTariff = parseTariff(kind, s.Value)
+				case 1: // Tariff switchover time
+					if len(s.Value) >= 1 {
+						// Time of day in steps of 15 minutes
+						minutes := int(s.Value[0]) * 15
+						info.SwitchoverTime = fmt.Sprintf("%02d:%02d", minutes/60%24, minutes%60)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Decode a TariffCurrencyFormat or a TariffPulseFormat
+func parseTariff(kind string, data []byte) *Tariff {
+	fields, err := parseBER(data)
+	if err != nil {
+		return nil
+	}
+	tariff := &Tariff{Kind: kind}
+
+	for _, f := range contextFields(fields) {
+		switch f.Tag {
+		case 0: // Communication charge sequence
+			entries, _ := parseBER(f.Value)
+			for _, entry := range entries {
+				if charge := parseCommunicationCharge(kind, entry.Value); charge != nil {
+					tariff.CommunicationCharges = append(tariff.CommunicationCharges, *charge)
+				}
+			}
+		case 1:
+			tariff.NonCyclic = len(f.Value) >= 2 && f.Value[1]&0x80 != 0
+		case 2:
+			tariff.CallAttemptCharge = parseTariffAmount(kind, f.Value)
+		case 3:
+			tariff.CallSetupCharge = parseTariffAmount(kind, f.Value)
+		}
+	}
+
+	return tariff
+}
+
+// Decode a CommunicationChargeCurrency or a CommunicationChargePulse
+func parseCommunicationCharge(kind string, data []byte) *TariffCharge {
+	fields, err := parseBER(data)
+	if err != nil {
+		return nil
+	}
+	charge := &TariffCharge{}
+
+	for _, f := range contextFields(fields) {
+		v := berInteger(f.Value)
+		switch {
+		case kind == TariffKindCurrency && f.Tag == 0:
+			charge.setCurrency(f.Value)
+		case kind == TariffKindCurrency && f.Tag == 1, kind == TariffKindPulse && f.Tag == 2:
+			charge.TariffDuration = &v
+		case kind == TariffKindPulse && f.Tag == 0:
+			charge.PulseUnits = &v
+		case kind == TariffKindPulse && f.Tag == 1:
+			charge.ChargeUnitTimeInterval = &v
+		}
+	}
+
+	return charge
+}
+
+// Decode a call attempt or call setup charge: a CurrencyFactorScale or PulseUnits
+func parseTariffAmount(kind string, data []byte) *TariffCharge {
+	charge := &TariffCharge{}
+	if kind == TariffKindPulse {
+		v := berInteger(data)
+		charge.PulseUnits = &v
+	} else {
+		charge.setCurrency(data)
+	}
+	return charge
+}
+
+// Decode the addOncharge choice: a CurrencyFactorScale or PulseUnits
+func parseAddOnCharge(data []byte) *TariffCharge {
+	choice, err := parseBER(data)
+	if err != nil || len(choice) == 0 {
+		return nil
+	}
+	if choice[0].Tag == 1 {
+		return parseTariffAmount(TariffKindPulse, choice[0].Value)
+	}
+	return parseTariffAmount(TariffKindCurrency, choice[0].Value)
+}
+
+// Decode a CurrencyFactorScale, the amount being factor x 10^scale
+func (charge *TariffCharge) setCurrency(data []byte) {
+	fields, err := parseBER(data)
+	if err != nil {
+		return
+	}
+	for _, f := range contextFields(fields) {
+		v := berInteger(f.Value)
+		switch f.Tag {
+		case 0:
+			charge.CurrencyFactor = &v
+		case 1:
+			charge.CurrencyScale = &v
+		}
+	}
+	if charge.CurrencyFactor != nil {
+		scale := int64(0)
+		if charge.CurrencyScale != nil {
+			scale = *charge.CurrencyScale
+		}
+		amount := float64(*charge.CurrencyFactor) * math.Pow10(int(scale))
+		charge.Amount = strconv.FormatFloat(amount, 'f', -1, 64)
+	}
+}
+
+// Keep the context-specific elements of a SEQUENCE
+func contextFields(elements []berElement) []berElement {
+	var fields []berElement
+	for _, el := range elements {
+		if el.Class == berClassContext {
+			fields = append(fields, el)
+		}
+	}
+	return fields
+}
+
+// Name the bits set in a BER BIT STRING, first bit first
+func berBitNames(data []byte, names []string) []string {
+	if len(data) < 2 {
+		return nil
+	}
+	var set []string
+	for i, name := range names {
+		octet := 1 + i/8
+		if octet < len(data) && data[octet]&(0x80>>(i%8)) != 0 {
+			set = append(set, name)
+		}
+	}
+	return set
+}
+
+// The currency is an ISO 4217 code, or a number when not printable
+func chargingCurrency(data []byte) string {
+	for _, b := range data {
+		if b < 0x20 || b > 0x7E {
+			return strconv.FormatInt(berInteger(data), 10)
+		}
+	}
+	return string(data)
+}
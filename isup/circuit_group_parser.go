@@ -0,0 +1,115 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseCircuitGroup decodes the circuit group supervision messages according to ITU-T Q.763 / ANSI T1.113
+// (GRS, GRA, CGB, CGBA, CGU, CGUA, CQM, CQR)
+func ParseCircuitGroup(messageType uint8, cic uint16, data []byte, variant Variant) (*CircuitGroupParameters, error) {
+	Len := len(data)
+	offset := 0
+	cg := &CircuitGroupParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	switch messageType {
+	case ISUPMessageTypeCGB, ISUPMessageTypeCGBA, ISUPMessageTypeCGU, ISUPMessageTypeCGUA:
+		if offset+1 > Len {
+			return nil, fmt.Errorf("missing Circuit Group Supervision Message Type")
+		}
+		cg.SupervisionType = parseCircuitGroupSupervisionType(data[offset])
+		cg.decoded(cg.add(ISUPCircuitGroupSupervisionMessageType, data[offset:offset+1]), "circuit_group.supervision_type", true)
+		offset++
+	}
+
+	/**
+	** Variable mandatory parameters
+	**/
+
+	// Range and Status
+	rangeStatus, err := readVariableParameter(data, offset)
+	if err != nil {
+		return nil, fmt.Errorf("missing Range and Status: %v", err)
+	}
+	if len(rangeStatus) < 1 {
+		return nil, fmt.Errorf("empty Range and Status")
+	}
+	cg.Range = rangeStatus[0]
+	cg.Circuits = expandRangeAndStatus(messageType, cic, rangeStatus, variant)
+	cg.decoded(cg.add(ISUPRangeAndStatus, rangeStatus), "circuit_group.range", true)
+
+	// Circuit State Indicator
+	if messageType == ISUPMessageTypeCQR {
+		states, err := readVariableParameter(data, offset+1)
+		if err != nil {
+			return nil, fmt.Errorf("missing Circuit State Indicator: %v", err)
+		}
+		for i := range cg.Circuits {
+			if i < len(states) {
+				cg.Circuits[i].State = parseCircuitState(states[i])
+			}
+		}
+		cg.decoded(cg.add(ISUPCircuitStateIndicator, states), "circuit_group.circuits", len(cg.Circuits) > 0)
+	}
+
+	return cg, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseCircuitGroupSupervisionType(value uint8) *CircuitGroupSupervisionType {
+	t := value & 0x03
+	return &CircuitGroupSupervisionType{
+		Num:  t,
+		Name: circuitGroupSupervisionTypeValues[t],
+	}
+}
+
+// Expand the Range and Status parameter into the list of affected circuits.
+// The range field counts the circuits after the one in the CIC field, and the
+// status bits follow the same order starting from bit A of the first status octet.
+func expandRangeAndStatus(messageType uint8, cic uint16, data []byte, variant Variant) []CircuitStatus {
+	count := int(data[0]) + 1
+	status := data[1:]
+	names := rangeStatusBitNames[messageType]
+
+	circuits := make([]CircuitStatus, 0, count)
+	for i := 0; i < count; i++ {
+		c := CircuitStatus{
+			CIC: (cic + uint16(i)) & variant.CICMask(),
+		}
+		if names != nil && i/8 < len(status) {
+			bit := (status[i/8] >> (i % 8)) & 0x01
+			c.Status = &bit
+			c.StatusName = names[bit]
+		}
+		circuits = append(circuits, c)
+	}
+
+	return circuits
+}
+
+func parseCircuitState(value uint8) *CircuitState {
+	state := &CircuitState{
+		Value:               value,
+		MaintenanceBlocking: value & 0x03,
+		CallProcessing:      (value >> 2) & 0x03,
+		HardwareBlocking:    (value >> 4) & 0x03,
+	}
+
+	// With no call processing state the circuit is either transient or unequipped
+	if state.CallProcessing == 0 {
+		state.CallProcessingName = circuitStateNoCallValues[state.MaintenanceBlocking]
+		return state
+	}
+
+	state.MaintenanceBlockingName = blockingStateValues[state.MaintenanceBlocking]
+	state.CallProcessingName = callProcessingStateValues[state.CallProcessing]
+	state.HardwareBlockingName = blockingStateValues[state.HardwareBlocking]
+
+	return state
+}
@@ -0,0 +1,142 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// Q.850 causes whose diagnostic names the offending message type or parameters
+const (
+	CauseMessageTypeNonExistent        = 97
+	CauseMessageNotCompatible          = 98
+	CauseParameterNonExistent          = 99
+	CauseInvalidParameterContents      = 100
+	CauseMessageNotCompatibleCallState = 101
+	CauseParameterPassedOn             = 103
+	CauseUnrecognizedParameter         = 110
+)
+
+// ParseCFN decodes a Confusion message according to ITU-T Q.763
+func ParseCFN(data []byte) (*CFNParameters, error) {
+	cfn := &CFNParameters{}
+
+	/**
+	** Variable mandatory parameters
+	**/
+	val, err := readVariableParameter(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("missing Cause Indicators: %v", err)
+	}
+	cfn.Cause = parseCauseIndicators(val)
+	cfn.decoded(cfn.add(ISUPCauseIndicators, val), "cfn.cause", cfn.Cause != nil)
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 1, func(code uint8, val []byte) {
+		param := cfn.add(code, val)
+		switch code {
+		case ISUPMessageCompatibilityInformation:
+			cfn.MessageCompatibility = parseMessageCompatibilityInformation(val)
+			cfn.decoded(param, "cfn.message_compatibility", cfn.MessageCompatibility != nil)
+		case ISUPParameterCompatibilityInformation:
+			cfn.ParameterCompatibility = parseParameterCompatibilityInformation(val)
+			cfn.decoded(param, "cfn.parameter_compatibility", cfn.ParameterCompatibility != nil)
+		}
+	})
+
+	return cfn, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Name the message type or the parameters reported in a cause diagnostic (Q.850 table 1)
+func causeDiagnosticNames(cause uint8, diagnostic []byte) []string {
+	var names []string
+
+	switch cause {
+	case CauseMessageTypeNonExistent, CauseMessageNotCompatible, CauseMessageNotCompatibleCallState:
+		if len(diagnostic) >= 1 {
+			names = append(names, GetISUPMessageTypeName(diagnostic[0]))
+		}
+	case CauseParameterNonExistent, CauseInvalidParameterContents, CauseParameterPassedOn, CauseUnrecognizedParameter:
+		for _, code := range diagnostic {
+			names = append(names, GetParameterName(code))
+		}
+	}
+
+	return names
+}
+
+// Parse a Message Compatibility Information parameter (ITU-T Q.763 3.33)
+func parseMessageCompatibilityInformation(data []byte) *InstructionIndicators {
+	if len(data) < 1 {
+		return nil
+	}
+	octet := data[0]
+
+	ind := &InstructionIndicators{
+		TransitAtIntermediateExchange: octet & 0x01,
+		ReleaseCall:                   (octet >> 1) & 0x01,
+		SendNotification:              (octet >> 2) & 0x01,
+		DiscardMessage:                (octet >> 3) & 0x01,
+		PassOnNotPossible:             (octet >> 4) & 0x01,
+		BroadbandInterworking:         (octet >> 5) & 0x03,
+	}
+	ind.TransitAtIntermediateExchangeName = transitAtIntermediateExchangeValues[ind.TransitAtIntermediateExchange]
+	ind.ReleaseCallName = releaseCallValues[ind.ReleaseCall]
+	ind.SendNotificationName = sendNotificationValues[ind.SendNotification]
+	ind.DiscardMessageName = discardMessageValues[ind.DiscardMessage]
+	ind.PassOnNotPossibleName = messagePassOnNotPossibleValues[ind.PassOnNotPossible]
+	ind.BroadbandInterworkingName = messageBroadbandInterworkingValues[ind.BroadbandInterworking]
+
+	return ind
+}
+
+// Parse a Parameter Compatibility Information parameter (ITU-T Q.763 3.41):
+// for each upgraded parameter, its name followed by its instruction indicators
+func parseParameterCompatibilityInformation(data []byte) []ParameterCompatibility {
+	var params []ParameterCompatibility
+
+	Len := len(data)
+	offset := 0
+	for offset+1 < Len {
+		param := ParameterCompatibility{
+			Parameter:     data[offset],
+			ParameterName: GetParameterName(data[offset]),
+		}
+		octet := data[offset+1]
+		offset += 2
+
+		ind := &InstructionIndicators{
+			TransitAtIntermediateExchange: octet & 0x01,
+			ReleaseCall:                   (octet >> 1) & 0x01,
+			SendNotification:              (octet >> 2) & 0x01,
+			DiscardMessage:                (octet >> 3) & 0x01,
+			DiscardParameter:              (octet >> 4) & 0x01,
+			PassOnNotPossible:             (octet >> 5) & 0x03,
+		}
+		ind.TransitAtIntermediateExchangeName = transitAtIntermediateExchangeValues[ind.TransitAtIntermediateExchange]
+		ind.ReleaseCallName = releaseCallValues[ind.ReleaseCall]
+		ind.SendNotificationName = sendNotificationValues[ind.SendNotification]
+		ind.DiscardMessageName = discardMessageValues[ind.DiscardMessage]
+		ind.DiscardParameterName = discardParameterValues[ind.DiscardParameter]
+		ind.PassOnNotPossibleName = parameterPassOnNotPossibleValues[ind.PassOnNotPossible]
+
+		// Second octet, present when the extension bit is 0
+		if octet&0x80 == 0 && offset < Len {
+			ind.BroadbandInterworking = data[offset] & 0x03
+			ind.BroadbandInterworkingName = parameterBroadbandInterworkingValues[ind.BroadbandInterworking]
+			for offset < Len && data[offset]&0x80 == 0 {
+				offset++
+			}
+			offset++
+		}
+
+		param.Instructions = ind
+		params = append(params, param)
+	}
+
+	return params
+}
@@ -27,6 +27,7 @@ const (
 	ISUPRangeAndStatus                      = 22  // Range and status spec: 3.43
 	ISUPFacilityIndicator                   = 24  // Facility indicator spec: 3.22
 	ISUPClosedUserGroupInterlockCode        = 26  // Closed user group interlock code spec: 3.15
+	ISUPCUGCheckResponseIndicators          = 28  // Closed user group check response indicators (Q.763 1988, CSVR only)
 	ISUPUserServiceInformation              = 29  // User service information spec: 3.57
 	ISUPSignallingPointCode                 = 30  // Signalling point code spec: 3.50
 	ISUPUserToUserInformation               = 32  // User-to-user information spec: 3.61
@@ -103,8 +104,12 @@ const (
 	ISUPNumberPortabilityForwardInformation = 141 // Number portability forward information spec: 3.101
 	ISUPGenericNumber                       = 192 // Generic number spec: 3.26
 	ISUPGenericDigits                       = 193 // Generic digits spec: 3.24
+	ISUPEgressService                       = 195 // Egress service (ANSI T1.113)
 	ISUPJurisdiction                        = 196 // Jurisdiction
+	ISUPCarrierIdentification               = 197 // Carrier identification (ANSI T1.113)
+	ISUPOriginatingLineInformation          = 234 // Originating line information (ANSI T1.113)
 	ISUPChargeNumber                        = 235 // Charge number spec: 3.1
+	ISUPCarrierSelectionInformation         = 238 // Carrier selection information (ANSI T1.113)
 )
 
 // Parameter name mapping for debugging/logging
@@ -133,6 +138,7 @@ var ISUPPameterNames = map[uint8]string{
 	ISUPRange:                               "Range",
 	ISUPFacilityIndicator:                   "Facility indicator",
 	ISUPClosedUserGroupInterlockCode:        "Closed user group interlock code",
+	ISUPCUGCheckResponseIndicators:          "Closed user group check response indicators",
 	ISUPUserServiceInformation:              "User service information",
 	ISUPSignallingPointCode:                 "Signalling point code",
 	ISUPUserToUserInformation:               "User-to-user information",
@@ -210,6 +216,11 @@ var ISUPPameterNames = map[uint8]string{
 	ISUPGenericNumber:                       "Generic number",
 	ISUPGenericDigits:                       "Generic digits",
 	ISUPJurisdiction:                        "Jurisdiction",
+	ISUPOriginatingLineInformation:          "Originating line information",
+	ISUPChargeNumber:                        "Charge number",
+	ISUPEgressService:                       "Egress service",
+	ISUPCarrierIdentification:               "Carrier identification",
+	ISUPCarrierSelectionInformation:         "Carrier selection information",
 }
 
 // Helper function to get parameter name
@@ -480,3 +491,800 @@ var queryOnReleaseIndicators = map[uint8]string{
 	0x00: "no QoR routing",
 	0x01: "QoR routing attempt",
 }
+
+// Circuit Group Supervision Message Type Indicators
+var circuitGroupSupervisionTypeValues = map[uint8]string{
+	0x00: "maintenance oriented",
+	0x01: "hardware failure oriented",
+	0x02: "reserved for national use",
+	0x03: "spare",
+}
+
+// Range and Status bit meanings per message type
+var rangeStatusBitNames = map[uint8]map[uint8]string{
+	ISUPMessageTypeCGB: {
+		0x00: "no blocking",
+		0x01: "blocking",
+	},
+	ISUPMessageTypeCGBA: {
+		0x00: "no blocking acknowledgement",
+		0x01: "blocking acknowledgement",
+	},
+	ISUPMessageTypeCGU: {
+		0x00: "no unblocking",
+		0x01: "unblocking",
+	},
+	ISUPMessageTypeCGUA: {
+		0x00: "no unblocking acknowledgement",
+		0x01: "unblocking acknowledgement",
+	},
+	ISUPMessageTypeGRA: {
+		0x00: "not blocked for maintenance reasons",
+		0x01: "blocked for maintenance reasons",
+	},
+}
+
+// Circuit State Indicator, circuit without call processing state
+var circuitStateNoCallValues = map[uint8]string{
+	0x00: "transient",
+	0x01: "spare",
+	0x02: "spare",
+	0x03: "unequipped",
+}
+
+// Circuit State Indicator, maintenance and hardware blocking states
+var blockingStateValues = map[uint8]string{
+	0x00: "no blocking (active)",
+	0x01: "locally blocked",
+	0x02: "remotely blocked",
+	0x03: "locally and remotely blocked",
+}
+
+// Circuit State Indicator, call processing states
+var callProcessingStateValues = map[uint8]string{
+	0x01: "circuit incoming busy",
+	0x02: "circuit outgoing busy",
+	0x03: "idle",
+}
+
+// Suspend/Resume Indicators
+var suspendResumeIndicators = map[uint8]string{
+	0x00: "ISDN subscriber initiated",
+	0x01: "network initiated",
+}
+
+// Information Request Indicators
+var requestedValues = map[uint8]string{
+	0x00: "not requested",
+	0x01: "requested",
+}
+
+// Information Indicators
+var callingPartyAddressResponseValues = map[uint8]string{
+	0x00: "calling party address not included",
+	0x01: "calling party address not available",
+	0x02: "spare",
+	0x03: "calling party address included",
+}
+
+var holdProvidedValues = map[uint8]string{
+	0x00: "hold not provided",
+	0x01: "hold provided",
+}
+
+var includedValues = map[uint8]string{
+	0x00: "not included",
+	0x01: "included",
+}
+
+var solicitedInformationValues = map[uint8]string{
+	0x00: "solicited",
+	0x01: "unsolicited",
+}
+
+// Continuity Indicators
+var continuityIndicators = map[uint8]string{
+	0x00: "continuity check failed",
+	0x01: "continuity",
+}
+
+// Cause Location Values (Q.850)
+var causeLocationValues = map[uint8]string{
+	0x00: "user",
+	0x01: "private network serving the local user",
+	0x02: "public network serving the local user",
+	0x03: "transit network",
+	0x04: "public network serving the remote user",
+	0x05: "private network serving the remote user",
+	0x07: "international network",
+	0x0A: "network beyond interworking point",
+}
+
+// Cause Values (Q.850)
+var causeValues = map[uint8]string{
+	1:   "unallocated (unassigned) number",
+	2:   "no route to specified transit network",
+	3:   "no route to destination",
+	4:   "send special information tone",
+	5:   "misdialled trunk prefix",
+	6:   "channel unacceptable",
+	7:   "call awarded and being delivered in an established channel",
+	8:   "preemption",
+	9:   "preemption - circuit reserved for reuse",
+	14:  "QoR: ported number",
+	16:  "normal call clearing",
+	17:  "user busy",
+	18:  "no user responding",
+	19:  "no answer from user (user alerted)",
+	20:  "subscriber absent",
+	21:  "call rejected",
+	22:  "number changed",
+	23:  "redirection to new destination",
+	25:  "exchange routing error",
+	26:  "non-selected user clearing",
+	27:  "destination out of order",
+	28:  "invalid number format (address incomplete)",
+	29:  "facility rejected",
+	30:  "response to STATUS ENQUIRY",
+	31:  "normal, unspecified",
+	34:  "no circuit/channel available",
+	38:  "network out of order",
+	39:  "permanent frame mode connection out of service",
+	40:  "permanent frame mode connection operational",
+	41:  "temporary failure",
+	42:  "switching equipment congestion",
+	43:  "access information discarded",
+	44:  "requested circuit/channel not available",
+	46:  "precedence call blocked",
+	47:  "resource unavailable, unspecified",
+	49:  "quality of service not available",
+	50:  "requested facility not subscribed",
+	53:  "outgoing calls barred within CUG",
+	55:  "incoming calls barred within CUG",
+	57:  "bearer capability not authorized",
+	58:  "bearer capability not presently available",
+	62:  "inconsistency in designated outgoing access information and subscriber class",
+	63:  "service or option not available, unspecified",
+	65:  "bearer capability not implemented",
+	66:  "channel type not implemented",
+	69:  "requested facility not implemented",
+	70:  "only restricted digital information bearer capability is available",
+	79:  "service or option not implemented, unspecified",
+	81:  "invalid call reference value",
+	82:  "identified channel does not exist",
+	83:  "a suspended call exists, but this call identity does not",
+	84:  "call identity in use",
+	85:  "no call suspended",
+	86:  "call having the requested call identity has been cleared",
+	87:  "user not member of CUG",
+	88:  "incompatible destination",
+	90:  "non-existent CUG",
+	91:  "invalid transit network selection",
+	95:  "invalid message, unspecified",
+	96:  "mandatory information element is missing",
+	97:  "message type non-existent or not implemented",
+	98:  "message not compatible with call state or message type non-existent or not implemented",
+	99:  "information element/parameter non-existent or not implemented",
+	100: "invalid information element contents",
+	101: "message not compatible with call state",
+	102: "recovery on timer expiry",
+	103: "parameter non-existent or not implemented, passed on",
+	110: "message with unrecognized parameter, discarded",
+	111: "protocol error, unspecified",
+	127: "interworking, unspecified",
+}
+
+// Facility Indicator Values
+var facilityIndicatorValues = map[uint8]string{
+	0x02: "user-to-user service",
+}
+
+// Remote Operations protocol profiles
+var protocolProfileValues = map[uint8]string{
+	0x11: "remote operations protocol",
+}
+
+// ROSE component types (context-specific tags)
+var roseComponentTypes = map[uint32]string{
+	1: "invoke",
+	2: "return_result",
+	3: "return_error",
+	4: "reject",
+}
+
+// ROSE reject problems, by problem tag then value
+var roseProblemValues = map[uint32]map[int64]string{
+	0: {
+		0: "general: unrecognized component",
+		1: "general: mistyped component",
+		2: "general: badly structured component",
+	},
+	1: {
+		0: "invoke: duplicate invocation",
+		1: "invoke: unrecognized operation",
+		2: "invoke: mistyped argument",
+		3: "invoke: resource limitation",
+		4: "invoke: initiator releasing",
+		5: "invoke: unrecognized linked ID",
+		6: "invoke: linked response unexpected",
+		7: "invoke: unexpected child operation",
+	},
+	2: {
+		0: "return result: unrecognized invocation",
+		1: "return result: result response unexpected",
+		2: "return result: mistyped result",
+	},
+	3: {
+		0: "return error: unrecognized invocation",
+		1: "return error: error response unexpected",
+		2: "return error: unrecognized error",
+		3: "return error: unexpected error",
+		4: "return error: mistyped parameter",
+	},
+}
+
+// ROSE local operation values (ETSI supplementary services)
+var roseOperationValues = map[int64]string{
+	6:  "EctExecute",
+	7:  "ActivationDiversion",
+	8:  "DeactivationDiversion",
+	9:  "ActivationStatusNotificationDiv",
+	10: "DeactivationStatusNotificationDiv",
+	11: "InterrogationDiversion",
+	12: "DiversionInformation",
+	13: "CallDeflection",
+	14: "CallRerouteing",
+	15: "DivertingLegInformation2",
+	17: "InterrogateServedUserNumbers",
+	18: "DivertingLegInformation1",
+	19: "DivertingLegInformation3",
+	21: "EctLinkIdRequest",
+	26: "EctInform",
+	27: "EctLoopTest",
+	30: "ChargingRequest",
+	31: "AOCSCurrency",
+	32: "AOCSSpecialArr",
+	33: "AOCDCurrency",
+	34: "AOCDChargingUnit",
+	35: "AOCECurrency",
+	36: "AOCEChargingUnit",
+	40: "CCBSRequest",
+	41: "CCBSDeactivate",
+	42: "CCBSInterrogate",
+	43: "CCBSErase",
+	44: "CCBSRemoteUserFree",
+	45: "CCBSCall",
+	46: "CCBSStatusRequest",
+	47: "CCBSBFree",
+	48: "EraseCallLinkageID",
+	49: "CCBSStopAlerting",
+}
+
+// User-to-User Indicators
+var uuIndicatorTypeValues = map[uint8]string{
+	0x00: "request",
+	0x01: "response",
+}
+
+var uuServiceRequestValues = map[uint8]string{
+	0x00: "no information",
+	0x01: "spare",
+	0x02: "request, not essential",
+	0x03: "request, essential",
+}
+
+var uuServiceResponseValues = map[uint8]string{
+	0x00: "no information",
+	0x01: "not provided",
+	0x02: "provided",
+	0x03: "spare",
+}
+
+var uuNetworkDiscardValues = map[uint8]string{
+	0x00: "no information",
+	0x01: "user-to-user information discarded by the network",
+}
+
+// User-to-User Information protocol discriminators (Q.931)
+var protocolDiscriminatorValues = map[uint8]string{
+	0x00: "user-specific protocol",
+	0x01: "OSI high layer protocols",
+	0x02: "X.244",
+	0x03: "reserved for system management convergence function",
+	0x04: "IA5 characters",
+	0x05: "X.208 and X.209 coded user information",
+	0x07: "rate adaption according to V.120",
+	0x08: "Q.931/I.451 user-network call control messages",
+}
+
+// Application transport parameter (Q.763 3.82)
+var applicationContextValues = map[uint16]string{
+	0: "Unidentified Context and Error Handling (UCEH) ASE",
+	1: "PSS1 ASE (VPN)",
+	2: "spare",
+	3: "Charging ASE",
+	4: "GAT",
+	5: "BAT ASE",
+	6: "Enhanced Unidentified Context and Error Handling ASE (EUCEH ASE)",
+}
+
+var sendNotificationValues = map[uint8]string{
+	0x00: "do not send notification",
+	0x01: "send notification",
+}
+
+var releaseCallValues = map[uint8]string{
+	0x00: "do not release call",
+	0x01: "release call",
+}
+
+var sequenceIndicatorValues = map[uint8]string{
+	0x00: "subsequent segment to first segment",
+	0x01: "new sequence",
+}
+
+// BAT ASE information element identifiers (Q.765.5)
+var batIdentifierValues = map[uint8]string{
+	0x01: "Action Indicator",
+	0x02: "Backbone Network Connection Identifier",
+	0x03: "Interworking Function Address",
+	0x04: "Codec List",
+	0x05: "Single Codec",
+	0x06: "BAT Compatibility Report",
+	0x07: "Bearer Network Connection Characteristics",
+	0x08: "Bearer Control Information",
+	0x09: "Bearer Control Tunnelling",
+	0x0A: "Bearer Control Unit Identifier",
+	0x0B: "Signal",
+	0x0C: "Bearer Redirection Capability",
+	0x0D: "Bearer Redirection Indicators",
+	0x0E: "Signal Type",
+	0x0F: "Duration",
+}
+
+var batActionIndicatorValues = map[uint8]string{
+	0x00: "no indication",
+	0x01: "connect backward",
+	0x02: "connect forward",
+	0x03: "connect forward, no notification",
+	0x04: "connect forward, plus notification",
+	0x05: "connect forward, no notification + selected codec",
+	0x06: "connect forward, plus notification + selected codec",
+	0x07: "use idle",
+	0x08: "connected",
+	0x09: "switched",
+	0x0A: "selected codec",
+	0x0B: "modify codec",
+	0x0C: "successful codec modification",
+	0x0D: "codec modification failure",
+	0x0E: "mid-call codec negotiation",
+	0x0F: "modify to selected codec information",
+	0x10: "mid-call codec negotiation failure",
+	0x11: "start signal notify",
+	0x12: "stop signal notify",
+	0x13: "start signal acknowledge",
+	0x14: "stop signal acknowledge",
+	0x15: "bearer redirect",
+}
+
+var codecOrganizationValues = map[uint8]string{
+	0x01: "ITU-T",
+	0x02: "ETSI (3GPP TS 26.103)",
+}
+
+// ITU-T codec types (Q.765.5)
+var itutCodecValues = map[uint8]string{
+	0x01: "G.711 64 kbit/s A-law",
+	0x02: "G.711 64 kbit/s mu-law",
+	0x03: "G.711 56 kbit/s A-law",
+	0x04: "G.711 56 kbit/s mu-law",
+	0x05: "G.722 (SB-ADPCM)",
+	0x06: "G.723.1",
+	0x07: "G.723.1 Annex A (silence compression)",
+	0x08: "G.726 (ADPCM)",
+	0x09: "G.727 (Embedded ADPCM)",
+	0x0A: "G.728",
+	0x0B: "G.729 (CS-ACELP)",
+	0x0C: "G.729 Annex B (silence compression)",
+}
+
+// 3GPP codec types (TS 26.103)
+var etsiCodecValues = map[uint8]string{
+	0x00: "GSM FR",
+	0x01: "GSM HR",
+	0x02: "GSM EFR",
+	0x03: "FR AMR",
+	0x04: "HR AMR",
+	0x05: "UMTS AMR",
+	0x06: "UMTS AMR 2",
+	0x07: "TDMA EFR",
+	0x08: "PDC EFR",
+	0x09: "FR AMR-WB",
+	0x0A: "UMTS AMR-WB",
+	0x0B: "OHR AMR",
+	0x0C: "OFR AMR-WB",
+	0x0D: "OHR AMR-WB",
+}
+
+// Charging ASE (ETSI ES 201 296)
+var chargingMessageTypes = map[uint8]string{
+	0: "charging tariff information (CRGT)",
+	1: "add-on charging information (AOCRG)",
+	2: "charging acknowledgement information (CRGA)",
+}
+
+var chargingControlIndicatorNames = []string{
+	"subscriber charge",
+	"immediate change of actually applied tariff",
+	"delay until start",
+}
+
+var chargingAcknowledgementIndicatorNames = []string{
+	"accepted",
+}
+
+// Message and parameter compatibility instruction indicators (Q.763 3.33 and 3.41)
+var transitAtIntermediateExchangeValues = map[uint8]string{
+	0x00: "transit interpretation",
+	0x01: "end node interpretation",
+}
+
+var discardMessageValues = map[uint8]string{
+	0x00: "do not discard message (pass on)",
+	0x01: "discard message",
+}
+
+var discardParameterValues = map[uint8]string{
+	0x00: "do not discard parameter (pass on)",
+	0x01: "discard parameter",
+}
+
+var messagePassOnNotPossibleValues = map[uint8]string{
+	0x00: "release call",
+	0x01: "discard information",
+}
+
+var parameterPassOnNotPossibleValues = map[uint8]string{
+	0x00: "release call",
+	0x01: "discard message",
+	0x02: "discard parameter",
+	0x03: "reserved (interpreted as release call)",
+}
+
+var messageBroadbandInterworkingValues = map[uint8]string{
+	0x00: "pass on",
+	0x01: "discard message",
+	0x02: "release call",
+	0x03: "reserved (interpreted as pass on)",
+}
+
+var parameterBroadbandInterworkingValues = map[uint8]string{
+	0x00: "pass on",
+	0x01: "discard message",
+	0x02: "release call",
+	0x03: "discard parameter",
+}
+
+// Event information (Q.763 3.21)
+var eventIndicatorValues = map[uint8]string{
+	0x00: "spare",
+	0x01: "ALERTING",
+	0x02: "PROGRESS",
+	0x03: "in-band information or an appropriate pattern is now available",
+	0x04: "call forwarded on busy (national use)",
+	0x05: "call forwarded on no reply (national use)",
+	0x06: "call forwarded unconditional (national use)",
+}
+
+var eventPresentationValues = map[uint8]string{
+	0x00: "no indication",
+	0x01: "presentation restricted",
+}
+
+// Redirection information (Q.763 3.45)
+var redirectingIndicatorValues = map[uint8]string{
+	0x00: "no redirection (national use)",
+	0x01: "call rerouted (national use)",
+	0x02: "call rerouted, all redirection information presentation restricted (national use)",
+	0x03: "call diverted",
+	0x04: "call diverted, all redirection information presentation restricted",
+	0x05: "call rerouted, redirection number presentation restricted (national use)",
+	0x06: "call diversion, redirection number presentation restricted (national use)",
+	0x07: "spare",
+}
+
+var redirectingReasonValues = map[uint8]string{
+	0x00: "unknown/not available",
+	0x01: "user busy",
+	0x02: "no reply",
+	0x03: "unconditional",
+	0x04: "deflection during alerting",
+	0x05: "deflection immediate response",
+	0x06: "mobile subscriber not reachable",
+}
+
+// Call diversion information (Q.763 3.6)
+var notificationSubscriptionValues = map[uint8]string{
+	0x00: "unknown",
+	0x01: "presentation not allowed",
+	0x02: "presentation allowed with redirection number",
+	0x03: "presentation allowed without redirection number",
+}
+
+// Redirect capability and status (Q.763 3.96 and 3.98)
+var redirectCapabilityValues = map[uint8]string{
+	0x00: "not used",
+	0x01: "redirect possible before ACM",
+	0x02: "redirect possible before ANM",
+	0x03: "redirect possible at any time during the call",
+}
+
+var redirectStatusValues = map[uint8]string{
+	0x00: "not used",
+	0x01: "acknowledgement of redirection",
+	0x02: "redirection will not be invoked",
+	0x03: "spare",
+}
+
+// Redirect forward/backward information element tags (Q.763 3.99 and 3.100)
+var redirectForwardInformationTags = map[uint8]string{
+	0x01: "return to invoking exchange possible",
+	0x02: "return to invoking exchange call identifier",
+	0x03: "performing redirect indicator",
+	0x04: "invoking redirect reason",
+}
+
+var redirectBackwardInformationTags = map[uint8]string{
+	0x01: "return to invoking exchange duration",
+	0x02: "return to invoking exchange call identifier",
+	0x03: "invoking redirect reason",
+}
+
+// Type of shape of a Calling Geodetic Location (Q.763 3.88)
+var geodeticShapeValues = map[uint8]string{
+	ShapeEllipsoidPoint:                  "ellipsoid point",
+	ShapeEllipsoidPointWithUncertainty:   "ellipsoid point with uncertainty",
+	ShapePointWithAltitudeAndUncertainty: "point with altitude and uncertainty",
+	ShapeEllipseOnTheEllipsoid:           "ellipse on the ellipsoid",
+	ShapeEllipsoidCircleSector:           "ellipsoid circle sector",
+	ShapePolygon:                         "polygon",
+}
+
+// Generic Digits encoding scheme (Q.763 3.24)
+var digitsEncodingValues = map[uint8]string{
+	DigitsEncodingBCDEven: "BCD even",
+	DigitsEncodingBCDOdd:  "BCD odd",
+	DigitsEncodingIA5:     "IA5 character",
+	DigitsEncodingBinary:  "binary coded",
+}
+
+// Generic Digits type of digits (Q.763 3.24)
+var typeOfDigitsValues = map[uint8]string{
+	0x00: "reserved for account code",
+	0x01: "reserved for authorisation code",
+	0x02: "reserved for private networking travelling class mark",
+	0x03: "reserved for business communication group identity",
+	0x1F: "reserved for extension",
+}
+
+// Generic Digits type of digits (ANSI T1.113 3.23)
+var ansiTypeOfDigitsValues = map[uint8]string{
+	0x00: "account code",
+	0x01: "authorization code",
+	0x02: "private network traveling class mark",
+	0x03: "business communication group identity",
+	0x04: "bill-to number",
+	0x1F: "reserved for extension",
+}
+
+// Q.931 information element identifiers (Q.931 4.5), single octet ones by their high nibble
+var informationElementValues = map[uint8]string{
+	IEBearerCapability:       "Bearer capability",
+	IECause:                  "Cause",
+	0x14:                     "Call state",
+	0x18:                     "Channel identification",
+	0x1C:                     "Facility",
+	IEProgressIndicator:      "Progress indicator",
+	0x20:                     "Network-specific facilities",
+	0x27:                     "Notification indicator",
+	0x28:                     "Display",
+	0x29:                     "Date/time",
+	0x2C:                     "Keypad facility",
+	0x34:                     "Signal",
+	0x4C:                     "Connected number",
+	0x4D:                     "Connected subaddress",
+	0x6C:                     "Calling party number",
+	IECallingPartySubaddress: "Calling party subaddress",
+	0x70:                     "Called party number",
+	IECalledPartySubaddress:  "Called party subaddress",
+	0x74:                     "Redirecting number",
+	0x78:                     "Transit network selection",
+	IELowLayerCompatibility:  "Low layer compatibility",
+	IEHighLayerCompatibility: "High layer compatibility",
+	0x7E:                     "User-user",
+	0x90:                     "Shift",
+	0xA0:                     "More data / sending complete",
+	0xB0:                     "Congestion level",
+	0xD0:                     "Repeat indicator",
+}
+
+// High layer characteristics identification (Q.931 4.5.17)
+var highLayerCharacteristicsValues = map[uint8]string{
+	0x01: "Telephony",
+	0x04: "Facsimile Group 2/3",
+	0x21: "Facsimile Group 4 Class I",
+	0x24: "Teletex service, basic and mixed mode / Facsimile Group 4 Classes II and III",
+	0x28: "Teletex service, basic and processable mode",
+	0x31: "Teletex service, basic mode",
+	0x32: "Syntax based Videotex",
+	0x33: "International Videotex interworking",
+	0x35: "Telex service",
+	0x38: "Message Handling Systems",
+	0x41: "OSI application",
+	0x42: "FTAM application",
+	0x5E: "Reserved for maintenance",
+	0x5F: "Reserved for management",
+	0x60: "Videotelephony",
+	0x61: "Videoconferencing",
+	0x62: "Audiographic conferencing",
+	0x68: "Multimedia services",
+}
+
+// Subaddress type (Q.931 4.5.9)
+var subaddressTypeValues = map[uint8]string{
+	SubaddressNSAP:          "NSAP (X.213/ISO 8348 AD2)",
+	SubaddressUserSpecified: "user specified",
+}
+
+// Progress description (Q.931 4.5.23)
+var progressDescriptionValues = map[uint8]string{
+	0x01: "call is not end-to-end ISDN; further call progress information may be available in-band",
+	0x02: "destination address is non-ISDN",
+	0x03: "origination address is non-ISDN",
+	0x04: "call has returned to the ISDN",
+	0x05: "interworking has occurred and has resulted in a telecommunication service change",
+	0x08: "in-band information or an appropriate pattern is now available",
+}
+
+// CUG call indicator (Q.763 3.38)
+var cugCallValues = map[uint8]string{
+	CUGCallNone:                     "non-CUG call",
+	0x01:                            "spare",
+	CUGCallOutgoingAccessAllowed:    "closed user group call, outgoing access allowed",
+	CUGCallOutgoingAccessNotAllowed: "closed user group call, outgoing access not allowed",
+}
+
+// Simple segmentation indicator (Q.763 3.38 and 3.37)
+var simpleSegmentationValues = map[uint8]string{
+	0x00: "no additional information will be sent",
+	0x01: "additional information will be sent in a segmentation message",
+}
+
+// MLPP precedence level (Q.763 3.34)
+var precedenceLevelValues = map[uint8]string{
+	PrecedenceFlashOverride: "flash override",
+	PrecedenceFlash:         "flash",
+	PrecedenceImmediate:     "immediate",
+	PrecedencePriority:      "priority",
+	PrecedenceRoutine:       "routine",
+}
+
+// Look ahead for busy (Q.763 3.34)
+var lookAheadForBusyValues = map[uint8]string{
+	0x00: "LFB allowed",
+	0x01: "path reserved (national use)",
+	0x02: "LFB not allowed",
+	0x03: "spare",
+}
+
+// Type of network identification (Q.763 3.53)
+var networkIdentificationTypeValues = map[uint8]string{
+	NetworkIdentificationITU:      "ITU-T standardized identification",
+	NetworkIdentificationNational: "national network identification",
+}
+
+// Network identification plan for an ITU-T standardized identification (Q.763 3.53)
+var networkIdentificationPlanValues = map[uint8]string{
+	0x00: "unknown",
+	0x03: "public data network identification code (X.121)",
+	0x06: "public land mobile network identification code (E.212)",
+}
+
+// Network identification plan for a national identification (ANSI T1.113)
+var ansiNetworkIdentificationPlanValues = map[uint8]string{
+	0x00:                   "unknown",
+	ANSICarrierCode3Digits: "3-digit carrier identification code",
+	ANSICarrierCode4Digits: "4-digit carrier identification code",
+}
+
+// Circuit code of an ANSI Transit Network Selection (ANSI T1.113)
+var circuitCodeValues = map[uint8]string{
+	0x00: "unspecified",
+	0x01: "international call, no operator requested",
+	0x02: "international call, operator requested",
+}
+
+// Carrier selection information (ANSI T1.113)
+var carrierSelectionValues = map[uint8]string{
+	0x00: "no indication",
+	0x01: "selected carrier identification code presubscribed and input by calling party",
+	0x02: "selected carrier identification code presubscribed and not input by calling party",
+	0x03: "selected carrier identification code presubscribed, no indication of whether input by calling party",
+	0x04: "selected carrier identification code not presubscribed and input by calling party",
+}
+
+// Nature of address of a Network Routing Number (Q.763 3.90)
+var networkRoutingNumberNatureValues = map[uint8]string{
+	0x00: "spare",
+	0x01: "network routing number in national (significant) number format (national use)",
+	0x02: "network routing number in network-specific number format (national use)",
+}
+
+// Number portability status indicator (Q.763 3.101)
+var portabilityStatusValues = map[uint8]string{
+	PortabilityNoIndication:     "no indication",
+	PortabilityQueryNotDone:     "number portability query not done for called number",
+	PortabilityQueryDoneNotPort: "number portability query done for called number, non-ported called subscriber",
+	PortabilityQueryDonePorted:  "number portability query done for called number, ported called subscriber",
+}
+
+// Type of address of an ANSI Generic Address (ANSI T1.113)
+var addressTypeValues = map[uint8]string{
+	0x00:                    "dialed number",
+	0x01:                    "destination number",
+	0x02:                    "supplemental user provided calling address, failed network screening",
+	0x03:                    "supplemental user provided calling address, not screened",
+	0x04:                    "completion number",
+	AddressTypePortedNumber: "ported number",
+}
+
+// Pivot possible indicator (Q.763 3.84)
+var pivotPossibleValues = map[uint8]string{
+	0x00: "no indication",
+	0x01: "pivot routing possible before ACM",
+	0x02: "pivot routing possible before ANM",
+	0x03: "pivot routing possible any time during the call",
+}
+
+// Interworking to redirection indicator (Q.763 3.84)
+var interworkingToRedirectionValues = map[uint8]string{
+	0x00: "allowed (forward)",
+	0x01: "not allowed (forward)",
+}
+
+// Pivot routing indicators (Q.763 3.85)
+var pivotRoutingIndicatorValues = map[uint8]string{
+	0x00:                "no indication",
+	PivotRequest:        "pivot request",
+	PivotCancelRequest:  "cancel pivot request",
+	PivotRequestFailure: "pivot request failure",
+	0x04:                "interworking to redirection prohibited (backward) (national use)",
+}
+
+// Pivot status (Q.763 3.92)
+var pivotStatusValues = map[uint8]string{
+	0x00: "not used",
+	0x01: "acknowledgment of pivot routing",
+	0x02: "pivot routing will not be invoked",
+	0x03: "spare",
+}
+
+// QoR capability (Q.763 3.91)
+var qorCapabilityValues = map[uint8]string{
+	0x00: "no QoR support",
+	0x01: "QoR support",
+}
+
+// Pivot routing forward/backward information element tags (Q.763 3.94 and 3.95)
+var pivotForwardInformationTags = map[uint8]string{
+	0x01: "return to invoking exchange possible",
+	0x02: "return to invoking exchange call identifier",
+	0x03: "performing pivot indicator",
+	0x04: "invoking pivot reason",
+}
+
+var pivotBackwardInformationTags = map[uint8]string{
+	0x01: "return to invoking exchange duration",
+	0x02: "return to invoking exchange call identifier",
+	0x03: "invoking pivot reason",
+}
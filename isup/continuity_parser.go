@@ -0,0 +1,25 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseCOT decodes a Continuity message according to ITU-T Q.763
+func ParseCOT(data []byte) (*COTParameters, error) {
+	Len := len(data)
+	if Len < 1 {
+		return nil, fmt.Errorf("missing Continuity Indicators")
+	}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	cont := data[0] & 0x01
+	cot := &COTParameters{
+		Continuity:     cont,
+		ContinuityName: continuityIndicators[cont],
+	}
+	cot.decoded(cot.add(ISUPContinuityIndicators, data[:1]), "cot.continuity", true)
+
+	return cot, nil
+}
@@ -0,0 +1,114 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// CUG call indicator of the Optional Forward Call Indicators (ITU-T Q.763 3.38)
+const (
+	CUGCallNone                     = 0x00
+	CUGCallOutgoingAccessAllowed    = 0x02
+	CUGCallOutgoingAccessNotAllowed = 0x03
+)
+
+// ParseCSVQ decodes a CUG Selection and Validation Request message according to ITU-T Q.763
+func ParseCSVQ(data []byte, variant Variant) (*CUGParameters, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("missing Calling Party Number")
+	}
+	cug := &CUGParameters{}
+
+	/**
+	** Variable mandatory parameters
+	**/
+	val, err := readVariableParameter(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("missing Calling Party Number: %v", err)
+	}
+	cug.CallingPartyNumber = parseNumberInfoCalling(val)
+	cug.decoded(cug.add(ISUPCallingPartyNumber, val), "cug.calling_party_number", cug.CallingPartyNumber != nil)
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 1, func(code uint8, val []byte) {
+		cug.parseOptional(code, val, variant)
+	})
+
+	return cug, nil
+}
+
+// ParseCSVR decodes a CUG Selection and Validation Response message according to ITU-T Q.763
+func ParseCSVR(data []byte, variant Variant) (*CUGParameters, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("missing CUG Check Response Indicators")
+	}
+	cug := &CUGParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	response := data[0] & 0x03
+	cug.CheckResponse = &response
+	cug.CheckResponseName = cugCallValues[response]
+	cug.decoded(cug.add(ISUPCUGCheckResponseIndicators, data[:1]), "cug.check_response", true)
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 1, func(code uint8, val []byte) {
+		cug.parseOptional(code, val, variant)
+	})
+
+	return cug, nil
+}
+
+// Optional parameters shared by CSVQ and CSVR
+func (cug *CUGParameters) parseOptional(code uint8, val []byte, variant Variant) {
+	param := cug.add(code, val)
+	switch code {
+	case ISUPOptionalForwardCallIndicators:
+		cug.OptionalForwardCall = parseOptionalForwardCall(val)
+		cug.decoded(param, "cug.optional_forward_call", cug.OptionalForwardCall != nil)
+	case ISUPClosedUserGroupInterlockCode:
+		cug.InterlockCode = parseCUGInterlockCode(val)
+		cug.decoded(param, "cug.interlock_code", cug.InterlockCode != nil)
+	case ISUPCallReference:
+		cug.CallReference = parseCallReference(val, variant)
+		cug.decoded(param, "cug.call_reference", cug.CallReference != nil)
+	}
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse an Optional Forward Call Indicators parameter (ITU-T Q.763 3.38)
+func parseOptionalForwardCall(data []byte) *OptionalForwardCall {
+	if len(data) < 1 {
+		return nil
+	}
+
+	indicators := &OptionalForwardCall{
+		CUGCall:                      data[0] & 0x03,
+		SimpleSegmentation:           (data[0] >> 2) & 0x01,
+		ConnectedLineIdentityRequest: (data[0] >> 7) & 0x01,
+	}
+	indicators.CUGCallName = cugCallValues[indicators.CUGCall]
+	indicators.SimpleSegmentationName = simpleSegmentationValues[indicators.SimpleSegmentation]
+	indicators.ConnectedLineIdentityRequestName = requestedValues[indicators.ConnectedLineIdentityRequest]
+
+	return indicators
+}
+
+// Parse a Closed User Group Interlock Code parameter (ITU-T Q.763 3.15)
+func parseCUGInterlockCode(data []byte) *CUGInterlockCode {
+	if len(data) < 4 {
+		return nil
+	}
+
+	return &CUGInterlockCode{
+		NetworkIdentity: decodeNetworkIdentity(data[:2]),
+		BinaryCode:      uint16(data[2])<<8 | uint16(data[3]),
+	}
+}
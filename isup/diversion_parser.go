@@ -0,0 +1,222 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Event indicators of a CPG reporting a diversion (ITU-T Q.763 3.21)
+const (
+	EventCallForwardedBusy          = 0x04
+	EventCallForwardedNoReply       = 0x05
+	EventCallForwardedUnconditional = 0x06
+)
+
+// ParseCPG decodes a Call Progress message according to ITU-T Q.763
+func ParseCPG(data []byte) (*CPGParameters, error) {
+	Len := len(data)
+	if Len < 2 {
+		return nil, fmt.Errorf("missing Event Information")
+	}
+	offset := 0
+	cpg := &CPGParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	cpg.EventInformation = parseEventInformation(data[offset])
+	cpg.decoded(cpg.add(ISUPEventInformation, data[offset:offset+1]), "cpg.event_information", true)
+	offset++
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, offset, func(code uint8, val []byte) {
+		param := cpg.add(code, val)
+		switch code {
+		case ISUPCallDiversionInformation:
+			cpg.CallDiversionInformation = parseCallDiversionInformation(val)
+			cpg.decoded(param, "cpg.call_diversion_information", cpg.CallDiversionInformation != nil)
+		case ISUPRedirectionNumber:
+			cpg.RedirectionNumber = parseNumberInfoCalled(val)
+			cpg.decoded(param, "cpg.redirection_number", cpg.RedirectionNumber != nil)
+		case ISUPRedirectionNumberRestriction:
+			cpg.RedirectionNumberRestriction = parseRedirectionNumberRestriction(val)
+			cpg.decoded(param, "cpg.redirection_number_restriction", cpg.RedirectionNumberRestriction != nil)
+		case ISUPRedirectStatus:
+			cpg.RedirectStatus = parseRedirectStatus(val)
+			cpg.decoded(param, "cpg.redirect_status", cpg.RedirectStatus != nil)
+		case ISUPTransmissionMediumUsed:
+			if len(val) >= 1 {
+				cpg.TransmissionMediumUsed = parseTransmissionMedium(val[0])
+			}
+			cpg.decoded(param, "cpg.transmission_medium_used", len(val) >= 1)
+		case ISUPAccessTransport:
+			cpg.AccessTransport = parseAccessTransport(val)
+			cpg.decoded(param, "cpg.access_transport", cpg.AccessTransport != nil)
+		case ISUPApplicationTransportParameter:
+			app := parseApplicationTransport(val)
+			if app != nil {
+				cpg.ApplicationTransport = append(cpg.ApplicationTransport, app)
+			}
+			cpg.decoded(param, elementPath("cpg.application_transport", len(cpg.ApplicationTransport)), app != nil)
+		}
+	})
+
+	return cpg, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseEventInformation(value uint8) *EventInformation {
+	event := &EventInformation{
+		Event:                  value & 0x7F,
+		PresentationRestricted: (value >> 7) & 0x01,
+	}
+	event.EventName = eventIndicatorValues[event.Event]
+	event.PresentationRestrictedName = eventPresentationValues[event.PresentationRestricted]
+
+	return event
+}
+
+// Parse a Redirecting Number or an Original Called Number (ITU-T Q.763 3.44 and 3.39)
+func parseNumberInfoRedirecting(data []byte) *NumberInfoRedirecting {
+
+	Len := len(data)
+
+	if Len < 2 {
+		return nil
+	}
+
+	info := &NumberInfoRedirecting{}
+
+	info.TON = data[0] & 0x7F
+	info.TONName = natureOfAddressValues[info.TON]
+	info.NPI = (data[1] >> 4) & 0x07
+	info.NPIName = npiValues[info.NPI]
+	info.Restrict = (data[1] >> 2) & 0x03
+	info.RestrictName = restrictValues[info.Restrict]
+
+	// Extract address digits
+	if Len > 2 {
+		info.Number = decodeBCDAddress(data[2:], (data[0]>>7)&0x01 == 1)
+	}
+
+	return info
+}
+
+// Parse a Redirection Information parameter (ITU-T Q.763 3.45)
+func parseRedirectionInformation(data []byte) *RedirectionInformation {
+	if len(data) < 1 {
+		return nil
+	}
+
+	info := &RedirectionInformation{
+		Indicator:      data[0] & 0x07,
+		OriginalReason: (data[0] >> 4) & 0x0F,
+	}
+	info.IndicatorName = redirectingIndicatorValues[info.Indicator]
+	info.OriginalReasonName = redirectingReasonValues[info.OriginalReason]
+
+	if len(data) >= 2 {
+		info.Counter = data[1] & 0x07
+		info.Reason = (data[1] >> 4) & 0x0F
+		info.ReasonName = redirectingReasonValues[info.Reason]
+	}
+
+	return info
+}
+
+// Parse a Call Diversion Information parameter (ITU-T Q.763 3.6)
+func parseCallDiversionInformation(data []byte) *CallDiversionInformation {
+	if len(data) < 1 {
+		return nil
+	}
+
+	info := &CallDiversionInformation{
+		NotificationOptions: data[0] & 0x07,
+		Reason:              (data[0] >> 3) & 0x0F,
+	}
+	info.NotificationOptionsName = notificationSubscriptionValues[info.NotificationOptions]
+	info.ReasonName = redirectingReasonValues[info.Reason]
+
+	return info
+}
+
+// Parse a Redirection Number Restriction parameter (ITU-T Q.763 3.47)
+func parseRedirectionNumberRestriction(data []byte) *RedirectionNumberRestriction {
+	if len(data) < 1 {
+		return nil
+	}
+
+	restriction := &RedirectionNumberRestriction{
+		Presentation: data[0] & 0x03,
+	}
+	restriction.PresentationName = restrictValues[restriction.Presentation]
+
+	return restriction
+}
+
+// Parse a Redirect Capability parameter (ITU-T Q.763 3.96)
+func parseRedirectCapability(data []byte) *RedirectCapability {
+	if len(data) < 1 {
+		return nil
+	}
+
+	capability := &RedirectCapability{
+		Capability: data[0] & 0x07,
+	}
+	capability.CapabilityName = redirectCapabilityValues[capability.Capability]
+
+	return capability
+}
+
+// Parse a Redirect Status parameter (ITU-T Q.763 3.98)
+func parseRedirectStatus(data []byte) *RedirectStatus {
+	if len(data) < 1 {
+		return nil
+	}
+
+	status := &RedirectStatus{
+		Status: data[0] & 0x03,
+	}
+	status.StatusName = redirectStatusValues[status.Status]
+
+	return status
+}
+
+// Parse a Redirect Counter parameter (ITU-T Q.763 3.97)
+func parseRedirectCounter(data []byte) *uint8 {
+	if len(data) < 1 {
+		return nil
+	}
+	counter := data[0] & 0x1F
+	return &counter
+}
+
+// Parse the information elements of a Redirect Forward/Backward Information parameter
+// (ITU-T Q.763 3.99 and 3.100): type tag, length and contents
+func parseRedirectInformationItems(data []byte, names map[uint8]string) []InformationItem {
+	var items []InformationItem
+
+	Len := len(data)
+	offset := 0
+	for offset+1 < Len {
+		tag := data[offset]
+		l := int(data[offset+1])
+		offset += 2
+		if offset+l > Len {
+			break
+		}
+		items = append(items, InformationItem{
+			Tag:     tag,
+			Name:    names[tag],
+			Content: hex.EncodeToString(data[offset : offset+l]),
+		})
+		offset += l
+	}
+
+	return items
+}
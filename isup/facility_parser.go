@@ -0,0 +1,194 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// ParseFacility decodes the FAC, FAR, FAA and FRJ messages according to ITU-T Q.763
+func ParseFacility(messageType uint8, data []byte, variant Variant) (*FacilityParameters, error) {
+	Len := len(data)
+	offset := 0
+	fac := &FacilityParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	if messageType != ISUPMessageTypeFAC {
+		if offset+1 > Len {
+			return nil, fmt.Errorf("missing Facility Indicator")
+		}
+		fac.FacilityIndicator = &FacilityIndicator{
+			Num:  data[offset],
+			Name: facilityIndicatorValues[data[offset]],
+		}
+		fac.decoded(fac.add(ISUPFacilityIndicator, data[offset:offset+1]), "facility.facility_indicator", true)
+		offset++
+	}
+
+	/**
+	** Variable mandatory parameters
+	**/
+	if messageType == ISUPMessageTypeFRJ {
+		val, err := readVariableParameter(data, offset)
+		if err != nil {
+			return nil, fmt.Errorf("missing Cause Indicators: %v", err)
+		}
+		fac.Cause = parseCauseIndicators(val)
+		fac.decoded(fac.add(ISUPCauseIndicators, val), "facility.cause", fac.Cause != nil)
+		offset++
+	}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, offset, func(code uint8, val []byte) {
+		param := fac.add(code, val)
+		switch code {
+		case ISUPCallReference:
+			fac.CallReference = parseCallReference(val, variant)
+			fac.decoded(param, "facility.call_reference", fac.CallReference != nil)
+		case ISUPRemoteOperations:
+			fac.RemoteOperations = parseRemoteOperations(val)
+			fac.decoded(param, "facility.remote_operations", fac.RemoteOperations != nil)
+		case ISUPPivotRoutingIndicators:
+			fac.PivotRoutingIndicators = parsePivotRoutingIndicators(val)
+			fac.decoded(param, "facility.pivot_routing_indicators", fac.PivotRoutingIndicators != nil)
+		case ISUPPivotStatus:
+			fac.PivotStatus = parsePivotStatus(val)
+			fac.decoded(param, "facility.pivot_status", fac.PivotStatus != nil)
+		case ISUPPivotCounter:
+			fac.PivotCounter = parsePivotCounter(val)
+			fac.decoded(param, "facility.pivot_counter", fac.PivotCounter != nil)
+		case ISUPPivotRoutingBackwardInformation:
+			fac.PivotRoutingBackwardInformation = parseRedirectInformationItems(val, pivotBackwardInformationTags)
+			fac.decoded(param, "facility.pivot_routing_backward_information", fac.PivotRoutingBackwardInformation != nil)
+		case ISUPRedirectionNumber:
+			fac.RedirectionNumber = parseNumberInfoCalled(val)
+			fac.decoded(param, "facility.redirection_number", fac.RedirectionNumber != nil)
+		}
+	})
+
+	return fac, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse the Remote Operations parameter and its ROSE components (ITU-T Q.932)
+func parseRemoteOperations(data []byte) *RemoteOperations {
+
+	Len := len(data)
+
+	if Len < 1 {
+		return nil
+	}
+
+	ro := &RemoteOperations{
+		ProtocolProfile: data[0] & 0x1F,
+	}
+	ro.ProtocolProfileName = protocolProfileValues[ro.ProtocolProfile]
+
+	// Skip the extension octets of the protocol profile
+	offset := 1
+	for offset < Len && data[offset-1]&0x80 == 0 {
+		offset++
+	}
+
+	elements, err := parseBER(data[offset:])
+	if err != nil {
+		ro.Error = err.Error()
+	}
+	for _, el := range elements {
+		ro.Components = append(ro.Components, parseROSEComponent(el))
+	}
+
+	return ro
+}
+
+func parseROSEComponent(el berElement) ROSEComponent {
+	comp := ROSEComponent{
+		Type: roseComponentTypes[el.Tag],
+	}
+	if el.Class != berClassContext || comp.Type == "" {
+		comp.Type = "unknown"
+		comp.Argument = hex.EncodeToString(el.Value)
+		return comp
+	}
+
+	fields, _ := parseBER(el.Value)
+
+	// Every component starts with the invoke ID (NULL in a Reject without one)
+	if len(fields) > 0 && fields[0].Class == berClassUniversal && fields[0].Tag == berTagInteger {
+		id := berInteger(fields[0].Value)
+		comp.InvokeID = &id
+	}
+	if len(fields) > 0 {
+		fields = fields[1:]
+	}
+
+	switch el.Tag {
+	case 1: // Invoke
+		if len(fields) > 0 && fields[0].Class == berClassContext && fields[0].Tag == 0 {
+			id := berInteger(fields[0].Value)
+			comp.LinkedID = &id
+			fields = fields[1:]
+		}
+		if len(fields) > 0 {
+			comp.setOperation(fields[0])
+			fields = fields[1:]
+		}
+		comp.setArgument(fields)
+	case 2: // ReturnResult
+		if len(fields) > 0 && fields[0].Tag == berTagSequence {
+			result, _ := parseBER(fields[0].Value)
+			if len(result) > 0 {
+				comp.setOperation(result[0])
+				comp.setArgument(result[1:])
+			}
+		}
+	case 3: // ReturnError
+		if len(fields) > 0 {
+			if fields[0].Tag == berTagOID {
+				comp.ErrorOID = berOID(fields[0].Value)
+			} else {
+				code := berInteger(fields[0].Value)
+				comp.ErrorCode = &code
+			}
+			comp.setArgument(fields[1:])
+		}
+	case 4: // Reject
+		if len(fields) > 0 && fields[0].Class == berClassContext {
+			problem := berInteger(fields[0].Value)
+			comp.Problem = roseProblemValues[fields[0].Tag][problem]
+			if comp.Problem == "" {
+				comp.Problem = fmt.Sprintf("unknown problem %d/%d", fields[0].Tag, problem)
+			}
+		}
+	}
+
+	return comp
+}
+
+// Set the local or global operation value
+func (comp *ROSEComponent) setOperation(el berElement) {
+	if el.Tag == berTagOID {
+		comp.OperationOID = berOID(el.Value)
+		return
+	}
+	code := berInteger(el.Value)
+	comp.OperationCode = &code
+	if name, exists := roseOperationValues[code]; exists {
+		comp.OperationName = name
+	} else {
+		comp.OperationName = "unknown"
+	}
+}
+
+// Keep the argument as hex, as it was encoded
+func (comp *ROSEComponent) setArgument(fields []berElement) {
+	for _, f := range fields {
+		comp.Argument += hex.EncodeToString(f.Raw)
+	}
+}
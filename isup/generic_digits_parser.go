@@ -0,0 +1,58 @@
+package isup
+
+import (
+	"encoding/hex"
+	"strconv"
+)
+
+// Encoding schemes of a Generic Digits parameter (ITU-T Q.763 3.24)
+const (
+	DigitsEncodingBCDEven = 0x00
+	DigitsEncodingBCDOdd  = 0x01
+	DigitsEncodingIA5     = 0x02
+	DigitsEncodingBinary  = 0x03
+)
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Generic Digits parameter (ITU-T Q.763 3.24, ANSI T1.113 3.23)
+func parseGenericDigits(data []byte, variant Variant) *GenericDigits {
+	if len(data) < 1 {
+		return nil
+	}
+
+	digits := &GenericDigits{
+		Type:     data[0] & 0x1F,
+		Encoding: (data[0] >> 5) & 0x07,
+	}
+	if variant == VariantANSI {
+		digits.TypeName = ansiTypeOfDigitsValues[digits.Type]
+	} else {
+		digits.TypeName = typeOfDigitsValues[digits.Type]
+	}
+	digits.EncodingName = digitsEncodingValues[digits.Encoding]
+
+	value := data[1:]
+	switch digits.Encoding {
+	case DigitsEncodingBCDEven, DigitsEncodingBCDOdd:
+		digits.Digits = decodeBCDAddress(value, digits.Encoding == DigitsEncodingBCDOdd)
+	case DigitsEncodingIA5:
+		digits.Digits = string(value)
+	case DigitsEncodingBinary:
+		if len(value) <= 8 {
+			// Binary coded value, most significant octet first
+			var number uint64
+			for _, b := range value {
+				number = number<<8 | uint64(b)
+			}
+			digits.Digits = strconv.FormatUint(number, 10)
+		}
+		digits.Data = hex.EncodeToString(value)
+	default:
+		digits.Data = hex.EncodeToString(value)
+	}
+
+	return digits
+}
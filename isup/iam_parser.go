@@ -4,8 +4,8 @@ import (
 	"fmt"
 )
 
-// ParseIAM decodes an IAM packet according to ITU-T Q.763
-func ParseIAM(data []byte) (*IAMParameters, error) {
+// ParseIAM decodes an IAM packet according to ITU-T Q.763, or ANSI T1.113 for the ANSI variant
+func ParseIAM(data []byte, variant Variant) (*IAMParameters, error) {
 	Len := len(data)
 	if Len < 7 {
 		return nil, fmt.Errorf("IAM too short")
@@ -21,6 +21,7 @@ func ParseIAM(data []byte) (*IAMParameters, error) {
 	}
 	// Nature of Connection Indicators
 	iam.NatureOfConnection = parseNatureOfConnection(data[offset])
+	iam.decoded(iam.add(ISUPNatureOfConnectionIndicators, data[offset:offset+1]), "iam.nature_of_connection", true)
 	offset++
 
 	if offset+2 > Len {
@@ -28,6 +29,7 @@ func ParseIAM(data []byte) (*IAMParameters, error) {
 	}
 	// Forward Call
 	iam.ForwardCall = parseForwardCall(data[offset : offset+2])
+	iam.decoded(iam.add(ISUPForwardCallIndicators, data[offset:offset+2]), "iam.forward_call", true)
 	offset += 2
 
 	if offset+1 > Len {
@@ -35,92 +37,209 @@ func ParseIAM(data []byte) (*IAMParameters, error) {
 	}
 	// Calling Party Category
 	iam.CallingPartyCategory = parseCallingPartyCat(data[offset])
+	iam.decoded(iam.add(ISUPCallingPartysCategory, data[offset:offset+1]), "iam.calling_party_category", true)
 	offset++
 
+	// ITU carries the Transmission Medium Requirement as a fixed octet,
+	// ANSI the User Service Information as a variable parameter
+	if variant != VariantANSI {
+		if offset+1 > Len {
+			return nil, fmt.Errorf("missing Transmission Medium Requirement")
+		}
+		iam.TransmissionMedium = parseTransmissionMedium(data[offset])
+		iam.decoded(iam.add(ISUPTransmissionMediumRequirement, data[offset:offset+1]), "iam.transmission_medium", true)
+		offset++
+	}
+
 	// Pointer table start
-	if Len < offset+3 {
+	ptrCount := 2
+	if variant == VariantANSI {
+		ptrCount = 3
+	}
+	if Len < offset+ptrCount {
 		return nil, fmt.Errorf("IAM missing pointer table")
 	}
 	ptrStart := offset
-	ptrUSI := int(data[ptrStart+0])      // pointer index 0
-	ptrCalled := int(data[ptrStart+1])   // pointer index 1
-	ptrOptional := int(data[ptrStart+2]) // pointer index 2
 
 	/**
 	** Variable mandatory parameters
 	**/
 
 	// User Service Information
-	if ptrUSI != 0 {
-		pPos := ptrStart + 0
-		base := pPos + ptrUSI
-		if base+1 <= Len {
-			l := int(data[base])
-			if base+1+l <= Len {
-				iam.UserServiceInformation = parseUserServiceInformation(data[base+1 : base+1+l])
-			}
+	if variant == VariantANSI {
+		if val, err := readVariableParameter(data, ptrStart); err == nil {
+			iam.UserServiceInformation = parseUserServiceInformation(val)
+			iam.decoded(iam.add(ISUPUserServiceInformation, val), "iam.user_service_information", iam.UserServiceInformation != nil)
 		}
+		ptrStart++
 	}
-
 	// Called Party Number
-	if ptrCalled != 0 {
-		pPos := ptrStart + 1
-		base := pPos + ptrCalled
-		if base+1 <= Len {
-			l := int(data[base])
-			if base+1+l <= Len {
-				iam.CalledPartyNumber = parseNumberInfoCalled(data[base+1 : base+1+l])
-			}
-		}
+	if val, err := readVariableParameter(data, ptrStart); err == nil {
+		iam.CalledPartyNumber = parseNumberInfoCalled(val)
+		iam.decoded(iam.add(ISUPCalledPartyNumber, val), "iam.called_party_number", iam.CalledPartyNumber != nil)
 	}
 
 	/**
 	** Optional parameters
 	**/
-	if ptrOptional != 0 {
-		pPos := ptrStart + 2
-		optStart := pPos + ptrOptional
-		offset = optStart
-		for offset < Len {
-			if offset >= Len {
-				break
-			}
-			t := data[offset]
-			offset++
-			if t == ISUPEndOfOptionalParameters {
-				break
-			}
-			if offset >= Len {
-				break
-			}
-			l := int(data[offset])
-			offset++
-			if offset+l > Len {
-				break
-			}
-			val := data[offset : offset+l]
-			offset += l
-
-			switch t {
-			case ISUPCallingPartyNumber:
-				iam.CallingPartyNumber = parseNumberInfoCalling(val)
-			case ISUPChargeNumber:
-				iam.ChargeNumber = parseNumberInfoCharge(val)
-			case ISUPHopCounter:
-				if len(val) >= 1 {
-					hop := val[0]
-					iam.HopCounter = &hop
-				}
-			case ISUPGenericNumber:
-				iam.GenericNumber = parseNumberInfoGeneric(val)
-			case ISUPJurisdiction:
-				j := parseJurisdictionDigits(val)
-				iam.Jurisdiction = &j
+	forEachOptionalParameter(data, ptrStart+1, func(code uint8, val []byte) {
+		iam.parseOptional(code, val, variant)
+	})
+
+	return iam, nil
+}
+
+// Optional parameters of the IAM, unknown codes are left to the generic parameter list
+func (iam *IAMParameters) parseOptional(code uint8, val []byte, variant Variant) {
+	param := iam.add(code, val)
+	switch code {
+	case ISUPOptionalForwardCallIndicators:
+		iam.OptionalForwardCall = parseOptionalForwardCall(val)
+		iam.decoded(param, "iam.optional_forward_call", iam.OptionalForwardCall != nil)
+	case ISUPClosedUserGroupInterlockCode:
+		iam.CUGInterlockCode = parseCUGInterlockCode(val)
+		iam.decoded(param, "iam.cug_interlock_code", iam.CUGInterlockCode != nil)
+	case ISUPTransmissionMediumRequirementPrime:
+		if len(val) >= 1 {
+			iam.TransmissionMediumPrime = parseTransmissionMedium(val[0])
+		}
+		iam.decoded(param, "iam.transmission_medium_prime", len(val) >= 1)
+	case ISUPUserServiceInformation:
+		iam.UserServiceInformation = parseUserServiceInformation(val)
+		iam.decoded(param, "iam.user_service_information", iam.UserServiceInformation != nil)
+	case ISUPMLPPPrecedence:
+		iam.MLPPPrecedence = parseMLPPPrecedence(val)
+		iam.decoded(param, "iam.mlpp_precedence", iam.MLPPPrecedence != nil)
+	case ISUPCallingPartyNumber:
+		iam.CallingPartyNumber = parseNumberInfoCalling(val)
+		iam.decoded(param, "iam.calling_party_number", iam.CallingPartyNumber != nil)
+	case ISUPChargeNumber:
+		iam.ChargeNumber = parseNumberInfoCharge(val)
+		iam.decoded(param, "iam.charge_number", iam.ChargeNumber != nil)
+	case ISUPHopCounter:
+		if len(val) >= 1 {
+			hop := val[0]
+			iam.HopCounter = &hop
+		}
+		iam.decoded(param, "iam.hop_counter", len(val) >= 1)
+	case ISUPGenericNumber:
+		// ANSI uses the code for the Generic Address
+		if variant == VariantANSI {
+			address := parseGenericAddress(val)
+			if address != nil {
+				iam.GenericAddresses = append(iam.GenericAddresses, address)
 			}
+			iam.decoded(param, elementPath("iam.generic_addresses", len(iam.GenericAddresses)), address != nil)
+		} else {
+			iam.GenericNumber = parseNumberInfoGeneric(val)
+			iam.decoded(param, "iam.generic_number", iam.GenericNumber != nil)
 		}
+	case ISUPCorrelationId:
+		iam.CorrelationID = parseCorrelationID(val, variant)
+		iam.decoded(param, "iam.correlation_id", iam.CorrelationID != nil)
+	case ISUPSCFId:
+		iam.SCFID = parseSCFID(val)
+		iam.decoded(param, "iam.scf_id", iam.SCFID != "")
+	case ISUPCalledINNumber:
+		iam.CalledINNumber = parseCalledINNumber(val)
+		iam.decoded(param, "iam.called_in_number", iam.CalledINNumber != nil)
+	case ISUPOriginalCalledINNumber:
+		iam.OriginalCalledINNumber = parseCalledINNumber(val)
+		iam.decoded(param, "iam.original_called_in_number", iam.OriginalCalledINNumber != nil)
+	case ISUPCalledDirectoryNumber:
+		iam.CalledDirectoryNumber = parseCalledDirectoryNumber(val)
+		iam.decoded(param, "iam.called_directory_number", iam.CalledDirectoryNumber != nil)
+	case ISUPNetworkRoutingNumber:
+		iam.NetworkRoutingNumber = parseNetworkRoutingNumber(val)
+		iam.decoded(param, "iam.network_routing_number", iam.NetworkRoutingNumber != nil)
+	case ISUPNumberPortabilityForwardInformation:
+		iam.NumberPortabilityForwardInformation = parseNumberPortabilityForwardInformation(val)
+		iam.decoded(param, "iam.number_portability_forward_information", iam.NumberPortabilityForwardInformation != nil)
+	case ISUPJurisdiction:
+		j := parseJurisdictionDigits(val)
+		iam.Jurisdiction = &j
+		iam.decoded(param, "iam.jurisdiction", true)
+	case ISUPGenericDigits:
+		digits := parseGenericDigits(val, variant)
+		if digits != nil {
+			iam.GenericDigits = append(iam.GenericDigits, digits)
+		}
+		iam.decoded(param, elementPath("iam.generic_digits", len(iam.GenericDigits)), digits != nil)
+	case ISUPLocationNumber:
+		iam.LocationNumber = parseNumberInfoLocation(val)
+		iam.decoded(param, "iam.location_number", iam.LocationNumber != nil)
+	case ISUPCallingGeodeticLocation:
+		iam.CallingGeodeticLocation = parseCallingGeodeticLocation(val)
+		iam.decoded(param, "iam.calling_geodetic_location", iam.CallingGeodeticLocation != nil)
+	case ISUPRedirectingNumber:
+		iam.RedirectingNumber = parseNumberInfoRedirecting(val)
+		iam.decoded(param, "iam.redirecting_number", iam.RedirectingNumber != nil)
+	case ISUPOriginalCalledNumber:
+		iam.OriginalCalledNumber = parseNumberInfoRedirecting(val)
+		iam.decoded(param, "iam.original_called_number", iam.OriginalCalledNumber != nil)
+	case ISUPRedirectionInformation:
+		iam.RedirectionInformation = parseRedirectionInformation(val)
+		iam.decoded(param, "iam.redirection_information", iam.RedirectionInformation != nil)
+	case ISUPRedirectCapability:
+		iam.RedirectCapability = parseRedirectCapability(val)
+		iam.decoded(param, "iam.redirect_capability", iam.RedirectCapability != nil)
+	case ISUPRedirectCounter:
+		iam.RedirectCounter = parseRedirectCounter(val)
+		iam.decoded(param, "iam.redirect_counter", iam.RedirectCounter != nil)
+	case ISUPRedirectForwardInformation:
+		iam.RedirectForwardInformation = parseRedirectInformationItems(val, redirectForwardInformationTags)
+		iam.decoded(param, "iam.redirect_forward_information", iam.RedirectForwardInformation != nil)
+	case ISUPAccessTransport:
+		iam.AccessTransport = parseAccessTransport(val)
+		iam.decoded(param, "iam.access_transport", iam.AccessTransport != nil)
+	case ISUPTransitNetworkSelection:
+		iam.TransitNetworkSelection = parseTransitNetworkSelection(val, variant)
+		iam.decoded(param, "iam.transit_network_selection", iam.TransitNetworkSelection != nil)
+	case ISUPCarrierIdentification:
+		iam.CarrierIdentification = parseCarrierIdentification(val, variant)
+		iam.decoded(param, "iam.carrier_identification", iam.CarrierIdentification != nil)
+	case ISUPCarrierSelectionInformation:
+		iam.CarrierSelection = parseCarrierSelectionInformation(val)
+		iam.decoded(param, "iam.carrier_selection", iam.CarrierSelection != nil)
+	case ISUPEgressService:
+		iam.EgressService = parseEgressService(val)
+		iam.decoded(param, "iam.egress_service", iam.EgressService != nil)
+	case ISUPPivotCapability:
+		iam.PivotCapability = parsePivotCapability(val)
+		iam.decoded(param, "iam.pivot_capability", iam.PivotCapability != nil)
+	case ISUPPivotCounter:
+		iam.PivotCounter = parsePivotCounter(val)
+		iam.decoded(param, "iam.pivot_counter", iam.PivotCounter != nil)
+	case ISUPPivotRoutingForwardInformation:
+		iam.PivotRoutingForwardInformation = parseRedirectInformationItems(val, pivotForwardInformationTags)
+		iam.decoded(param, "iam.pivot_routing_forward_information", iam.PivotRoutingForwardInformation != nil)
+	case ISUPQoRCapability:
+		iam.QoRCapability = parseQoRCapability(val)
+		iam.decoded(param, "iam.qor_capability", iam.QoRCapability != nil)
+	case ISUPOriginatingLineInformation:
+		if len(val) >= 1 {
+			iam.OriginatingLineInformation = &OriginatingLineInformation{Value: val[0]}
+		}
+		iam.decoded(param, "iam.originating_line_information", len(val) >= 1)
+	case ISUPUserToUserIndicators:
+		iam.UserToUserIndicators = parseUserToUserIndicators(val)
+		iam.decoded(param, "iam.user_to_user_indicators", iam.UserToUserIndicators != nil)
+	case ISUPUserToUserInformation:
+		iam.UserToUserInformation = parseUserToUserInformation(val)
+		iam.decoded(param, "iam.user_to_user_information", iam.UserToUserInformation != nil)
+	case ISUPApplicationTransportParameter:
+		app := parseApplicationTransport(val)
+		if app != nil {
+			iam.ApplicationTransport = append(iam.ApplicationTransport, app)
+		}
+		iam.decoded(param, elementPath("iam.application_transport", len(iam.ApplicationTransport)), app != nil)
+	case ISUPMessageCompatibilityInformation:
+		iam.MessageCompatibility = parseMessageCompatibilityInformation(val)
+		iam.decoded(param, "iam.message_compatibility", iam.MessageCompatibility != nil)
+	case ISUPParameterCompatibilityInformation:
+		iam.ParameterCompatibility = parseParameterCompatibilityInformation(val)
+		iam.decoded(param, "iam.parameter_compatibility", iam.ParameterCompatibility != nil)
 	}
-
-	return iam, nil
 }
 
 /**
@@ -170,9 +289,9 @@ func parseForwardCall(data []byte) *ForwardCall {
 		ISDNAccessName:                isdnAccessIndicators[byte2&0x01],
 		SCCPMethod:                    (byte2 >> 1) & 0x03,
 		SCCPMethodName:                sccpMethodIndicators[(byte2>>1)&0x03],
-		PortedNumber:                  (byte2 >> 4) & 0x01,
+		PortedNumber:                  (byte2 >> 3) & 0x01,
 		PortedNumberName:              portedNumberIndicators[(byte2>>3)&0x01],
-		QueryOnRelease:                (byte2 >> 5) & 0x01,
+		QueryOnRelease:                (byte2 >> 4) & 0x01,
 		QueryOnReleaseName:            queryOnReleaseIndicators[(byte2>>4)&0x01],
 	}
 }
@@ -0,0 +1,141 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseINR decodes an Information Request message according to ITU-T Q.763
+func ParseINR(data []byte, variant Variant) (*INRParameters, error) {
+	Len := len(data)
+	if Len < 2 {
+		return nil, fmt.Errorf("missing Information Request Indicators")
+	}
+	offset := 0
+	inr := &INRParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	inr.Indicators = parseInformationRequestIndicators(data[offset : offset+2])
+	inr.decoded(inr.add(ISUPInformationRequestIndicators, data[offset:offset+2]), "inr.indicators", true)
+	offset += 2
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, offset, func(code uint8, val []byte) {
+		param := inr.add(code, val)
+		switch code {
+		case ISUPCallReference:
+			inr.CallReference = parseCallReference(val, variant)
+			inr.decoded(param, "inr.call_reference", inr.CallReference != nil)
+		}
+	})
+
+	return inr, nil
+}
+
+// ParseINF decodes an Information message according to ITU-T Q.763
+func ParseINF(data []byte, variant Variant) (*INFParameters, error) {
+	Len := len(data)
+	if Len < 2 {
+		return nil, fmt.Errorf("missing Information Indicators")
+	}
+	offset := 0
+	inf := &INFParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	inf.Indicators = parseInformationIndicators(data[offset : offset+2])
+	inf.decoded(inf.add(ISUPInformationIndicators, data[offset:offset+2]), "inf.indicators", true)
+	offset += 2
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, offset, func(code uint8, val []byte) {
+		param := inf.add(code, val)
+		switch code {
+		case ISUPCallingPartysCategory:
+			if len(val) >= 1 {
+				inf.CallingPartyCategory = parseCallingPartyCat(val[0])
+			}
+			inf.decoded(param, "inf.calling_party_category", len(val) >= 1)
+		case ISUPCallingPartyNumber:
+			inf.CallingPartyNumber = parseNumberInfoCalling(val)
+			inf.decoded(param, "inf.calling_party_number", inf.CallingPartyNumber != nil)
+		case ISUPChargeNumber:
+			inf.ChargeNumber = parseNumberInfoCharge(val)
+			inf.decoded(param, "inf.charge_number", inf.ChargeNumber != nil)
+		case ISUPCallReference:
+			inf.CallReference = parseCallReference(val, variant)
+			inf.decoded(param, "inf.call_reference", inf.CallReference != nil)
+		}
+	})
+
+	return inf, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseInformationRequestIndicators(data []byte) *InformationRequestIndicators {
+	octet := data[0]
+
+	ind := &InformationRequestIndicators{
+		CallingPartyAddress:         octet & 0x01,
+		Holding:                     (octet >> 1) & 0x01,
+		CallingPartyCategory:        (octet >> 3) & 0x01,
+		ChargeInformation:           (octet >> 4) & 0x01,
+		MaliciousCallIdentification: (octet >> 7) & 0x01,
+	}
+	ind.CallingPartyAddressName = requestedValues[ind.CallingPartyAddress]
+	ind.HoldingName = requestedValues[ind.Holding]
+	ind.CallingPartyCategoryName = requestedValues[ind.CallingPartyCategory]
+	ind.ChargeInformationName = requestedValues[ind.ChargeInformation]
+	ind.MaliciousCallIdentificationName = requestedValues[ind.MaliciousCallIdentification]
+
+	return ind
+}
+
+func parseInformationIndicators(data []byte) *InformationIndicators {
+	octet := data[0]
+
+	ind := &InformationIndicators{
+		CallingPartyAddress:  octet & 0x03,
+		HoldProvided:         (octet >> 2) & 0x01,
+		CallingPartyCategory: (octet >> 5) & 0x01,
+		ChargeInformation:    (octet >> 6) & 0x01,
+		Solicited:            (octet >> 7) & 0x01,
+	}
+	ind.CallingPartyAddressName = callingPartyAddressResponseValues[ind.CallingPartyAddress]
+	ind.HoldProvidedName = holdProvidedValues[ind.HoldProvided]
+	ind.CallingPartyCategoryName = includedValues[ind.CallingPartyCategory]
+	ind.ChargeInformationName = includedValues[ind.ChargeInformation]
+	ind.SolicitedName = solicitedInformationValues[ind.Solicited]
+
+	return ind
+}
+
+// Requested returns the names of the information items requested by the INR
+func (ind *InformationRequestIndicators) Requested() []string {
+	var items []string
+	if ind.CallingPartyAddress == 1 {
+		items = append(items, "calling party address")
+	}
+	if ind.Holding == 1 {
+		items = append(items, "holding")
+	}
+	if ind.CallingPartyCategory == 1 {
+		items = append(items, "calling party's category")
+	}
+	if ind.ChargeInformation == 1 {
+		items = append(items, "charge information")
+	}
+	if ind.MaliciousCallIdentification == 1 {
+		items = append(items, "malicious call identification")
+	}
+	return items
+}
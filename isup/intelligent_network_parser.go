@@ -0,0 +1,36 @@
+package isup
+
+import (
+	"encoding/hex"
+)
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Correlation Id parameter (ITU-T Q.763 3.70), coded as Generic Digits
+func parseCorrelationID(data []byte, variant Variant) *GenericDigits {
+	return parseGenericDigits(data, variant)
+}
+
+// Parse an SCF Id parameter (ITU-T Q.763 3.71). Its coding is network specific and kept raw.
+func parseSCFID(data []byte) string {
+	return hex.EncodeToString(data)
+}
+
+// Parse a Called IN Number or an Original Called IN Number (ITU-T Q.763 3.73 and 3.87),
+// coded as an Original Called Number
+func parseCalledINNumber(data []byte) *NumberInfoRedirecting {
+	return parseNumberInfoRedirecting(data)
+}
+
+// Parse a Called Directory Number parameter (ITU-T Q.763 3.86), coded as a Called Party Number
+func parseCalledDirectoryNumber(data []byte) *NumberInfoCalled {
+	return parseNumberInfoCalled(data)
+}
+
+// INTriggered tells whether the call went through a service control function
+func (iam *IAMParameters) INTriggered() bool {
+	return iam.CorrelationID != nil || iam.SCFID != "" || iam.CalledINNumber != nil ||
+		iam.OriginalCalledINNumber != nil || iam.CalledDirectoryNumber != nil
+}
@@ -6,6 +6,8 @@ import (
 
 // IAMParameters struct
 type IAMParameters struct {
+	parameterList
+
 	// Mandatory parameters
 	NatureOfConnection   *NatureOfConnection `json:"nature_of_connection"`
 	ForwardCall          *ForwardCall        `json:"forward_call"`
@@ -15,17 +17,99 @@ type IAMParameters struct {
 	TransmissionMedium     *TransmissionMedium     `json:"transmission_medium,omitempty"`
 	UserServiceInformation *UserServiceInformation `json:"user_service_information,omitempty"`
 	// Optional parameters
-	CallingPartyNumber *NumberInfoCalling `json:"calling_party_number,omitempty"`
-	ChargeNumber       *NumberInfoCharge  `json:"charge_number,omitempty"`
-	HopCounter         *uint8             `json:"hop_counter,omitempty"`
-	GenericNumber      *NumberInfoGeneric `json:"generic_number,omitempty"`
-	Jurisdiction       *string            `json:"jurisdiction,omitempty"`
+	TransmissionMediumPrime *TransmissionMedium  `json:"transmission_medium_prime,omitempty"` // Fallback medium
+	OptionalForwardCall     *OptionalForwardCall `json:"optional_forward_call,omitempty"`
+	CUGInterlockCode        *CUGInterlockCode    `json:"cug_interlock_code,omitempty"`
+	MLPPPrecedence          *MLPPPrecedence      `json:"mlpp_precedence,omitempty"`
+	CallingPartyNumber      *NumberInfoCalling   `json:"calling_party_number,omitempty"`
+	ChargeNumber            *NumberInfoCharge    `json:"charge_number,omitempty"`
+	HopCounter              *uint8               `json:"hop_counter,omitempty"`
+	GenericNumber           *NumberInfoGeneric   `json:"generic_number,omitempty"`
+	Jurisdiction            *string              `json:"jurisdiction,omitempty"`
+	GenericDigits           []*GenericDigits     `json:"generic_digits,omitempty"` // May be repeated
+	// Location of the calling party
+	LocationNumber          *NumberInfoLocation      `json:"location_number,omitempty"`
+	CallingGeodeticLocation *CallingGeodeticLocation `json:"calling_geodetic_location,omitempty"`
+	// Diversion and redirection
+	RedirectingNumber          *NumberInfoRedirecting  `json:"redirecting_number,omitempty"`
+	OriginalCalledNumber       *NumberInfoRedirecting  `json:"original_called_number,omitempty"`
+	RedirectionInformation     *RedirectionInformation `json:"redirection_information,omitempty"`
+	RedirectCapability         *RedirectCapability     `json:"redirect_capability,omitempty"`
+	RedirectCounter            *uint8                  `json:"redirect_counter,omitempty"`
+	RedirectForwardInformation []InformationItem       `json:"redirect_forward_information,omitempty"`
+	// ISDN access information (Q.931 information elements)
+	AccessTransport []*InformationElement `json:"access_transport,omitempty"`
+	// Intelligent network
+	CorrelationID          *GenericDigits         `json:"correlation_id,omitempty"`
+	SCFID                  string                 `json:"scf_id,omitempty"` // Hex
+	CalledINNumber         *NumberInfoRedirecting `json:"called_in_number,omitempty"`
+	OriginalCalledINNumber *NumberInfoRedirecting `json:"original_called_in_number,omitempty"`
+	CalledDirectoryNumber  *NumberInfoCalled      `json:"called_directory_number,omitempty"`
+	// Number portability
+	NetworkRoutingNumber                *NetworkRoutingNumber                `json:"network_routing_number,omitempty"`
+	NumberPortabilityForwardInformation *NumberPortabilityForwardInformation `json:"number_portability_forward_information,omitempty"`
+	GenericAddresses                    []*GenericAddress                    `json:"generic_addresses,omitempty"` // ANSI, may be repeated
+	// Carrier selection
+	TransitNetworkSelection *NetworkIdentification `json:"transit_network_selection,omitempty"`
+	CarrierIdentification   *NetworkIdentification `json:"carrier_identification,omitempty"` // ANSI
+	CarrierSelection        *CarrierSelection      `json:"carrier_selection,omitempty"`      // ANSI
+	EgressService           *EgressService         `json:"egress_service,omitempty"`         // ANSI
+	// Pivot routing and query on release
+	PivotCapability                *PivotCapability  `json:"pivot_capability,omitempty"`
+	PivotCounter                   *uint8            `json:"pivot_counter,omitempty"`
+	PivotRoutingForwardInformation []InformationItem `json:"pivot_routing_forward_information,omitempty"`
+	QoRCapability                  *QoRCapability    `json:"qor_capability,omitempty"`
+	// Billing (ANSI)
+	OriginatingLineInformation *OriginatingLineInformation `json:"originating_line_information,omitempty"`
+	// User-to-user signalling
+	UserToUserIndicators  *UserToUserIndicators  `json:"user_to_user_indicators,omitempty"`
+	UserToUserInformation *UserToUserInformation `json:"user_to_user_information,omitempty"`
+	// Application transport mechanism
+	ApplicationTransport []*ApplicationTransport `json:"application_transport,omitempty"`
+	// Compatibility instructions for the receiving exchange
+	MessageCompatibility   *InstructionIndicators   `json:"message_compatibility,omitempty"`
+	ParameterCompatibility []ParameterCompatibility `json:"parameter_compatibility,omitempty"`
 }
 
 /**
 ** Parameter structures
 **/
 
+type OptionalForwardCall struct {
+	CUGCall                          uint8  `json:"cug_call"`
+	CUGCallName                      string `json:"cug_call_name"`
+	SimpleSegmentation               uint8  `json:"simple_segmentation"`
+	SimpleSegmentationName           string `json:"simple_segmentation_name"`
+	ConnectedLineIdentityRequest     uint8  `json:"connected_line_identity_request"`
+	ConnectedLineIdentityRequestName string `json:"connected_line_identity_request_name"`
+}
+
+type CUGInterlockCode struct {
+	NetworkIdentity string `json:"network_identity"`
+	BinaryCode      uint16 `json:"binary_code"`
+}
+
+type MLPPPrecedence struct {
+	Level           uint8  `json:"level"`
+	LevelName       string `json:"level_name"`
+	LFB             uint8  `json:"lfb"` // Look ahead for busy
+	LFBName         string `json:"lfb_name"`
+	NetworkIdentity string `json:"network_identity"`
+	ServiceDomain   uint32 `json:"service_domain"`
+}
+
+// CUGParameters struct (CSVQ and CSVR)
+type CUGParameters struct {
+	parameterList
+
+	CheckResponse       *uint8               `json:"check_response,omitempty"` // CSVR only
+	CheckResponseName   string               `json:"check_response_name,omitempty"`
+	CallingPartyNumber  *NumberInfoCalling   `json:"calling_party_number,omitempty"` // CSVQ only
+	OptionalForwardCall *OptionalForwardCall `json:"optional_forward_call,omitempty"`
+	InterlockCode       *CUGInterlockCode    `json:"interlock_code,omitempty"`
+	CallReference       *CallReference       `json:"call_reference,omitempty"`
+}
+
 type NatureOfConnection struct {
 	Satellite           uint8  `json:"satellite"`
 	SatelliteName       string `json:"satellite_name"`
@@ -111,6 +195,88 @@ type NumberInfoGeneric struct {
 	Number       string `json:"num"`
 }
 
+type GenericDigits struct {
+	Type         uint8  `json:"type"`
+	TypeName     string `json:"type_name"`
+	Encoding     uint8  `json:"encoding"`
+	EncodingName string `json:"encoding_name"`
+	Digits       string `json:"digits,omitempty"`
+	Data         string `json:"data,omitempty"` // Hex, binary or unknown encodings
+}
+
+type NumberInfoLocation struct {
+	INN          uint8  `json:"inn"`
+	INNName      string `json:"inn_name"`
+	TON          uint8  `json:"ton"`
+	TONName      string `json:"ton_name"`
+	NPI          uint8  `json:"npi"`
+	NPIName      string `json:"npi_name"`
+	Restrict     uint8  `json:"restrict"`
+	RestrictName string `json:"restrict_name"`
+	Screened     uint8  `json:"screened"`
+	ScreenedName string `json:"screened_name"`
+	Number       string `json:"num"`
+}
+
+type CallingGeodeticLocation struct {
+	Restrict                  uint8           `json:"restrict"`
+	RestrictName              string          `json:"restrict_name"`
+	Screened                  uint8           `json:"screened"`
+	ScreenedName              string          `json:"screened_name"`
+	Shape                     uint8           `json:"shape"`
+	ShapeName                 string          `json:"shape_name"`
+	Points                    []GeodeticPoint `json:"points,omitempty"`
+	UncertaintyMeters         *float64        `json:"uncertainty_meters,omitempty"`
+	UncertaintyMinorMeters    *float64        `json:"uncertainty_minor_meters,omitempty"`
+	OrientationDegrees        *int            `json:"orientation_degrees,omitempty"`    // Major axis, or offset angle of a circle sector
+	IncludedAngleDegrees      *int            `json:"included_angle_degrees,omitempty"` // Circle sector
+	AltitudeMeters            *int            `json:"altitude_meters,omitempty"`
+	AltitudeUncertaintyMeters *float64        `json:"altitude_uncertainty_meters,omitempty"`
+	Confidence                *uint8          `json:"confidence,omitempty"`
+	Description               string          `json:"description,omitempty"` // Shapes not decoded
+}
+
+type GeodeticPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// InformationElement is a Q.931 information element carried by Access Transport
+type InformationElement struct {
+	ID                     uint8                   `json:"id"`
+	Name                   string                  `json:"name"`
+	Data                   string                  `json:"data,omitempty"` // Hex contents
+	BearerCapability       *UserServiceInformation `json:"bearer_capability,omitempty"`
+	LowLayerCompatibility  *UserServiceInformation `json:"low_layer_compatibility,omitempty"`
+	HighLayerCompatibility *HighLayerCompatibility `json:"high_layer_compatibility,omitempty"`
+	Subaddress             *Subaddress             `json:"subaddress,omitempty"`
+	ProgressIndicator      *ProgressIndicator      `json:"progress_indicator,omitempty"`
+}
+
+type HighLayerCompatibility struct {
+	CodingStandard      string `json:"coding_standard"`
+	Interpretation      uint8  `json:"interpretation"`
+	Characteristics     uint8  `json:"characteristics"`
+	CharacteristicsName string `json:"characteristics_name"`
+	Extended            *uint8 `json:"extended,omitempty"`
+}
+
+type Subaddress struct {
+	Type     uint8  `json:"type"`
+	TypeName string `json:"type_name"`
+	Odd      bool   `json:"odd"`
+	Address  string `json:"address,omitempty"` // NSAP with IA5 characters
+	Data     string `json:"data,omitempty"`    // Hex subaddress information
+}
+
+type ProgressIndicator struct {
+	CodingStandard  string `json:"coding_standard"`
+	Location        uint8  `json:"location"`
+	LocationName    string `json:"location_name"`
+	Description     uint8  `json:"description"`
+	DescriptionName string `json:"description_name"`
+}
+
 type TransmissionMedium struct {
 	Num  uint8  `json:"num"`
 	Name string `json:"name"`
@@ -141,6 +307,51 @@ type AddressField struct {
 	RawBytes                 []byte `json:"-"`
 }
 
+type NetworkRoutingNumber struct {
+	TON     uint8  `json:"ton"`
+	TONName string `json:"ton_name"`
+	NPI     uint8  `json:"npi"`
+	NPIName string `json:"npi_name"`
+	Number  string `json:"num"`
+}
+
+type NumberPortabilityForwardInformation struct {
+	Status     uint8  `json:"status"`
+	StatusName string `json:"status_name"`
+}
+
+type GenericAddress struct {
+	Type         uint8  `json:"type"`
+	TypeName     string `json:"type_name"`
+	TON          uint8  `json:"ton"`
+	TONName      string `json:"ton_name"`
+	NPI          uint8  `json:"npi"`
+	NPIName      string `json:"npi_name"`
+	Restrict     uint8  `json:"restrict"`
+	RestrictName string `json:"restrict_name"`
+	Number       string `json:"num"`
+}
+
+type NetworkIdentification struct {
+	Type            uint8  `json:"type"`
+	TypeName        string `json:"type_name"`
+	Plan            uint8  `json:"plan"`
+	PlanName        string `json:"plan_name"`
+	Network         string `json:"network"`
+	CircuitCode     *uint8 `json:"circuit_code,omitempty"` // ANSI Transit Network Selection
+	CircuitCodeName string `json:"circuit_code_name,omitempty"`
+}
+
+type CarrierSelection struct {
+	Value uint8  `json:"value"`
+	Name  string `json:"name"`
+}
+
+type EgressService struct {
+	Text string `json:"text,omitempty"`
+	Data string `json:"data"` // Hex
+}
+
 type OriginatingLineInformation struct {
 	Value uint8 `json:"value"`
 }
@@ -160,17 +371,575 @@ type Jurisdiction struct {
 	Digits string `json:"digits"`
 }
 
+// CircuitGroupParameters struct (GRS, GRA, CGB, CGBA, CGU, CGUA, CQM, CQR)
+type CircuitGroupParameters struct {
+	parameterList
+
+	SupervisionType *CircuitGroupSupervisionType `json:"supervision_type,omitempty"` // CGB, CGBA, CGU, CGUA only
+	Range           uint8                        `json:"range"`
+	Circuits        []CircuitStatus              `json:"circuits"`
+}
+
+type CircuitGroupSupervisionType struct {
+	Num  uint8  `json:"num"`
+	Name string `json:"name"`
+}
+
+// CircuitStatus describes one circuit covered by a Range and Status parameter
+type CircuitStatus struct {
+	CIC        uint16        `json:"cic"`
+	Status     *uint8        `json:"status,omitempty"` // bit from the status field, absent for range-only messages
+	StatusName string        `json:"status_name,omitempty"`
+	State      *CircuitState `json:"state,omitempty"` // CQR only
+}
+
+// CircuitState is a decoded Circuit State Indicator octet
+type CircuitState struct {
+	Value                   uint8  `json:"value"`
+	MaintenanceBlocking     uint8  `json:"maintenance_blocking"`
+	MaintenanceBlockingName string `json:"maintenance_blocking_name"`
+	CallProcessing          uint8  `json:"call_processing"`
+	CallProcessingName      string `json:"call_processing_name"`
+	HardwareBlocking        uint8  `json:"hardware_blocking"`
+	HardwareBlockingName    string `json:"hardware_blocking_name"`
+}
+
+// SuspendResumeParameters struct (SUS, RES)
+type SuspendResumeParameters struct {
+	parameterList
+
+	Indicator     uint8          `json:"indicator"`
+	IndicatorName string         `json:"indicator_name"`
+	CallReference *CallReference `json:"call_reference,omitempty"`
+}
+
+type CallReference struct {
+	CallIdentity uint32 `json:"call_identity"`
+	PointCode    uint32 `json:"point_code"`
+}
+
+// INRParameters struct
+type INRParameters struct {
+	parameterList
+
+	Indicators    *InformationRequestIndicators `json:"indicators"`
+	CallReference *CallReference                `json:"call_reference,omitempty"`
+}
+
+// INFParameters struct
+type INFParameters struct {
+	parameterList
+
+	Indicators           *InformationIndicators `json:"indicators"`
+	CallingPartyCategory *CallingPartyCat       `json:"calling_party_category,omitempty"`
+	CallingPartyNumber   *NumberInfoCalling     `json:"calling_party_number,omitempty"`
+	ChargeNumber         *NumberInfoCharge      `json:"charge_number,omitempty"`
+	CallReference        *CallReference         `json:"call_reference,omitempty"`
+}
+
+type InformationRequestIndicators struct {
+	CallingPartyAddress             uint8  `json:"calling_party_address"`
+	CallingPartyAddressName         string `json:"calling_party_address_name"`
+	Holding                         uint8  `json:"holding"`
+	HoldingName                     string `json:"holding_name"`
+	CallingPartyCategory            uint8  `json:"calling_party_category"`
+	CallingPartyCategoryName        string `json:"calling_party_category_name"`
+	ChargeInformation               uint8  `json:"charge_information"`
+	ChargeInformationName           string `json:"charge_information_name"`
+	MaliciousCallIdentification     uint8  `json:"malicious_call_identification"`
+	MaliciousCallIdentificationName string `json:"malicious_call_identification_name"`
+}
+
+type InformationIndicators struct {
+	CallingPartyAddress      uint8  `json:"calling_party_address"`
+	CallingPartyAddressName  string `json:"calling_party_address_name"`
+	HoldProvided             uint8  `json:"hold_provided"`
+	HoldProvidedName         string `json:"hold_provided_name"`
+	CallingPartyCategory     uint8  `json:"calling_party_category"`
+	CallingPartyCategoryName string `json:"calling_party_category_name"`
+	ChargeInformation        uint8  `json:"charge_information"`
+	ChargeInformationName    string `json:"charge_information_name"`
+	Solicited                uint8  `json:"solicited"`
+	SolicitedName            string `json:"solicited_name"`
+}
+
+// IDRParameters struct
+type IDRParameters struct {
+	parameterList
+
+	Indicators *MCIDRequestIndicators `json:"indicators,omitempty"`
+}
+
+// IDSParameters struct
+type IDSParameters struct {
+	parameterList
+
+	Indicators         *MCIDResponseIndicators `json:"indicators,omitempty"`
+	CallingPartyNumber *NumberInfoCalling      `json:"calling_party_number,omitempty"`
+	GenericNumber      *NumberInfoGeneric      `json:"generic_number,omitempty"`
+	AccessTransport    []*InformationElement   `json:"access_transport,omitempty"`
+}
+
+type MCIDRequestIndicators struct {
+	MCID        uint8  `json:"mcid"`
+	MCIDName    string `json:"mcid_name"`
+	Holding     uint8  `json:"holding"`
+	HoldingName string `json:"holding_name"`
+}
+
+type MCIDResponseIndicators struct {
+	MCID             uint8  `json:"mcid"`
+	MCIDName         string `json:"mcid_name"`
+	HoldProvided     uint8  `json:"hold_provided"`
+	HoldProvidedName string `json:"hold_provided_name"`
+}
+
+// COTParameters struct
+type COTParameters struct {
+	parameterList
+
+	Continuity     uint8  `json:"continuity"`
+	ContinuityName string `json:"continuity_name"`
+}
+
+// FacilityParameters struct (FAC, FAR, FAA, FRJ)
+type FacilityParameters struct {
+	parameterList
+
+	FacilityIndicator *FacilityIndicator `json:"facility_indicator,omitempty"` // FAR, FAA, FRJ only
+	Cause             *CauseIndicators   `json:"cause,omitempty"`              // FRJ only
+	CallReference     *CallReference     `json:"call_reference,omitempty"`
+	RemoteOperations  *RemoteOperations  `json:"remote_operations,omitempty"`
+	// Pivot routing
+	PivotRoutingIndicators          *PivotRoutingIndicators `json:"pivot_routing_indicators,omitempty"`
+	PivotStatus                     *PivotStatus            `json:"pivot_status,omitempty"`
+	PivotCounter                    *uint8                  `json:"pivot_counter,omitempty"`
+	PivotRoutingBackwardInformation []InformationItem       `json:"pivot_routing_backward_information,omitempty"`
+	RedirectionNumber               *NumberInfoCalled       `json:"redirection_number,omitempty"`
+}
+
+type FacilityIndicator struct {
+	Num  uint8  `json:"num"`
+	Name string `json:"name"`
+}
+
+type CauseIndicators struct {
+	CodingStandard string `json:"coding_standard"`
+	Location       uint8  `json:"location"`
+	LocationName   string `json:"location_name"`
+	CauseValue     uint8  `json:"cause_value"`
+	CauseName      string `json:"cause_name"`
+	Diagnostic     string `json:"diagnostic,omitempty"` // Hex
+	// Message type or parameters named by the diagnostic
+	DiagnosticNames []string `json:"diagnostic_names,omitempty"`
+}
+
+// CFNParameters struct
+type CFNParameters struct {
+	parameterList
+
+	Cause                  *CauseIndicators         `json:"cause"`
+	MessageCompatibility   *InstructionIndicators   `json:"message_compatibility,omitempty"`
+	ParameterCompatibility []ParameterCompatibility `json:"parameter_compatibility,omitempty"`
+}
+
+// InstructionIndicators tell an exchange what to do with an unrecognised message or parameter
+type InstructionIndicators struct {
+	TransitAtIntermediateExchange     uint8  `json:"transit_at_intermediate_exchange"`
+	TransitAtIntermediateExchangeName string `json:"transit_at_intermediate_exchange_name"`
+	ReleaseCall                       uint8  `json:"release_call"`
+	ReleaseCallName                   string `json:"release_call_name"`
+	SendNotification                  uint8  `json:"send_notification"`
+	SendNotificationName              string `json:"send_notification_name"`
+	DiscardMessage                    uint8  `json:"discard_message"`
+	DiscardMessageName                string `json:"discard_message_name"`
+	DiscardParameter                  uint8  `json:"discard_parameter,omitempty"` // Parameter compatibility only
+	DiscardParameterName              string `json:"discard_parameter_name,omitempty"`
+	PassOnNotPossible                 uint8  `json:"pass_on_not_possible"`
+	PassOnNotPossibleName             string `json:"pass_on_not_possible_name"`
+	BroadbandInterworking             uint8  `json:"broadband_interworking"`
+	BroadbandInterworkingName         string `json:"broadband_interworking_name,omitempty"`
+}
+
+type ParameterCompatibility struct {
+	Parameter     uint8                  `json:"parameter"`
+	ParameterName string                 `json:"parameter_name"`
+	Instructions  *InstructionIndicators `json:"instructions"`
+}
+
+type RemoteOperations struct {
+	ProtocolProfile     uint8           `json:"protocol_profile"`
+	ProtocolProfileName string          `json:"protocol_profile_name"`
+	Components          []ROSEComponent `json:"components"`
+	Error               string          `json:"error,omitempty"` // Undecodable component data
+}
+
+// ROSEComponent is one Invoke, ReturnResult, ReturnError or Reject component
+type ROSEComponent struct {
+	Type          string `json:"type"`
+	InvokeID      *int64 `json:"invoke_id,omitempty"`
+	LinkedID      *int64 `json:"linked_id,omitempty"`
+	OperationCode *int64 `json:"operation_code,omitempty"` // Local operation value
+	OperationOID  string `json:"operation_oid,omitempty"`  // Global operation value
+	OperationName string `json:"operation_name,omitempty"`
+	ErrorCode     *int64 `json:"error_code,omitempty"`
+	ErrorOID      string `json:"error_oid,omitempty"`
+	Problem       string `json:"problem,omitempty"`  // Reject only
+	Argument      string `json:"argument,omitempty"` // Hex, argument/result/parameter
+}
+
+// ACMParameters struct
+type ACMParameters struct {
+	parameterList
+
+	UserToUserIndicators   *UserToUserIndicators    `json:"user_to_user_indicators,omitempty"`
+	UserToUserInformation  *UserToUserInformation   `json:"user_to_user_information,omitempty"`
+	TransmissionMediumUsed *TransmissionMedium      `json:"transmission_medium_used,omitempty"`
+	AccessTransport        []*InformationElement    `json:"access_transport,omitempty"`
+	ApplicationTransport   []*ApplicationTransport  `json:"application_transport,omitempty"`
+	MessageCompatibility   *InstructionIndicators   `json:"message_compatibility,omitempty"`
+	ParameterCompatibility []ParameterCompatibility `json:"parameter_compatibility,omitempty"`
+	// Diversion and redirection
+	CallDiversionInformation     *CallDiversionInformation     `json:"call_diversion_information,omitempty"`
+	RedirectionNumber            *NumberInfoCalled             `json:"redirection_number,omitempty"`
+	RedirectionNumberRestriction *RedirectionNumberRestriction `json:"redirection_number_restriction,omitempty"`
+	RedirectStatus               *RedirectStatus               `json:"redirect_status,omitempty"`
+}
+
+// ANMParameters struct
+type ANMParameters struct {
+	parameterList
+
+	UserToUserIndicators   *UserToUserIndicators    `json:"user_to_user_indicators,omitempty"`
+	UserToUserInformation  *UserToUserInformation   `json:"user_to_user_information,omitempty"`
+	TransmissionMediumUsed *TransmissionMedium      `json:"transmission_medium_used,omitempty"`
+	AccessTransport        []*InformationElement    `json:"access_transport,omitempty"`
+	ApplicationTransport   []*ApplicationTransport  `json:"application_transport,omitempty"`
+	MessageCompatibility   *InstructionIndicators   `json:"message_compatibility,omitempty"`
+	ParameterCompatibility []ParameterCompatibility `json:"parameter_compatibility,omitempty"`
+}
+
+// CPGParameters struct
+type CPGParameters struct {
+	parameterList
+
+	EventInformation             *EventInformation             `json:"event_information"`
+	CallDiversionInformation     *CallDiversionInformation     `json:"call_diversion_information,omitempty"`
+	RedirectionNumber            *NumberInfoCalled             `json:"redirection_number,omitempty"`
+	RedirectionNumberRestriction *RedirectionNumberRestriction `json:"redirection_number_restriction,omitempty"`
+	RedirectStatus               *RedirectStatus               `json:"redirect_status,omitempty"`
+	TransmissionMediumUsed       *TransmissionMedium           `json:"transmission_medium_used,omitempty"`
+	AccessTransport              []*InformationElement         `json:"access_transport,omitempty"`
+	ApplicationTransport         []*ApplicationTransport       `json:"application_transport,omitempty"`
+}
+
+type EventInformation struct {
+	Event                      uint8  `json:"event"`
+	EventName                  string `json:"event_name"`
+	PresentationRestricted     uint8  `json:"presentation_restricted"`
+	PresentationRestrictedName string `json:"presentation_restricted_name"`
+}
+
+type NumberInfoRedirecting struct {
+	TON          uint8  `json:"ton"`
+	TONName      string `json:"ton_name"`
+	NPI          uint8  `json:"npi"`
+	NPIName      string `json:"npi_name"`
+	Restrict     uint8  `json:"restrict"`
+	RestrictName string `json:"restrict_name"`
+	Number       string `json:"num"`
+}
+
+type RedirectionInformation struct {
+	Indicator          uint8  `json:"indicator"`
+	IndicatorName      string `json:"indicator_name"`
+	OriginalReason     uint8  `json:"original_reason"`
+	OriginalReasonName string `json:"original_reason_name"`
+	Counter            uint8  `json:"counter"`
+	Reason             uint8  `json:"reason"`
+	ReasonName         string `json:"reason_name"`
+}
+
+type CallDiversionInformation struct {
+	NotificationOptions     uint8  `json:"notification_options"`
+	NotificationOptionsName string `json:"notification_options_name"`
+	Reason                  uint8  `json:"reason"`
+	ReasonName              string `json:"reason_name"`
+}
+
+type RedirectionNumberRestriction struct {
+	Presentation     uint8  `json:"presentation"`
+	PresentationName string `json:"presentation_name"`
+}
+
+type RedirectCapability struct {
+	Capability     uint8  `json:"capability"`
+	CapabilityName string `json:"capability_name"`
+}
+
+type RedirectStatus struct {
+	Status     uint8  `json:"status"`
+	StatusName string `json:"status_name"`
+}
+
+// PivotCapability tells whether pivot routing is possible, and its interworking with redirection
+type PivotCapability struct {
+	Capability                    uint8  `json:"capability"`
+	CapabilityName                string `json:"capability_name"`
+	InterworkingToRedirection     uint8  `json:"interworking_to_redirection"`
+	InterworkingToRedirectionName string `json:"interworking_to_redirection_name"`
+}
+
+// PivotRoutingIndicators requests or acknowledges pivot routing
+type PivotRoutingIndicators struct {
+	Indicator     uint8  `json:"indicator"`
+	IndicatorName string `json:"indicator_name"`
+}
+
+// PivotStatus reports the outcome of a pivot routing request
+type PivotStatus struct {
+	Status     uint8  `json:"status"`
+	StatusName string `json:"status_name"`
+}
+
+// QoRCapability tells whether the exchange supports query on release
+type QoRCapability struct {
+	Capability     uint8  `json:"capability"`
+	CapabilityName string `json:"capability_name"`
+}
+
+// InformationItem is one tagged element of a redirect or pivot routing information parameter
+type InformationItem struct {
+	Tag     uint8  `json:"tag"`
+	Name    string `json:"name"`
+	Content string `json:"content"` // Hex
+}
+
+// RELParameters struct
+type RELParameters struct {
+	parameterList
+
+	Cause                  *CauseIndicators         `json:"cause"`
+	UserToUserIndicators   *UserToUserIndicators    `json:"user_to_user_indicators,omitempty"`
+	UserToUserInformation  *UserToUserInformation   `json:"user_to_user_information,omitempty"`
+	MessageCompatibility   *InstructionIndicators   `json:"message_compatibility,omitempty"`
+	ParameterCompatibility []ParameterCompatibility `json:"parameter_compatibility,omitempty"`
+	// Diversion and redirection
+	RedirectionNumber           *NumberInfoCalled       `json:"redirection_number,omitempty"`
+	RedirectionInformation      *RedirectionInformation `json:"redirection_information,omitempty"`
+	RedirectCounter             *uint8                  `json:"redirect_counter,omitempty"`
+	RedirectBackwardInformation []InformationItem       `json:"redirect_backward_information,omitempty"`
+}
+
+// USRParameters struct
+type USRParameters struct {
+	parameterList
+
+	UserToUserInformation *UserToUserInformation `json:"user_to_user_information"`
+	CallReference         *CallReference         `json:"call_reference,omitempty"`
+}
+
+type UserToUserIndicators struct {
+	Type               uint8  `json:"type"`
+	TypeName           string `json:"type_name"`
+	Service1           uint8  `json:"service1"`
+	Service1Name       string `json:"service1_name"`
+	Service2           uint8  `json:"service2"`
+	Service2Name       string `json:"service2_name"`
+	Service3           uint8  `json:"service3"`
+	Service3Name       string `json:"service3_name"`
+	NetworkDiscard     uint8  `json:"network_discard"`
+	NetworkDiscardName string `json:"network_discard_name"`
+}
+
+type UserToUserInformation struct {
+	ProtocolDiscriminator     uint8  `json:"protocol_discriminator"`
+	ProtocolDiscriminatorName string `json:"protocol_discriminator_name"`
+	Text                      string `json:"text,omitempty"` // IA5 payload
+	Data                      string `json:"data,omitempty"` // Hex, any other payload
+}
+
+// SEGParameters struct
+type SEGParameters struct {
+	parameterList
+
+	UserToUserInformation *UserToUserInformation `json:"user_to_user_information,omitempty"`
+	GenericNumber         *NumberInfoGeneric     `json:"generic_number,omitempty"`
+}
+
+// APTParameters struct
+type APTParameters struct {
+	parameterList
+
+	ApplicationTransport []*ApplicationTransport `json:"application_transport"`
+}
+
+type ApplicationTransport struct {
+	ContextID                  uint16               `json:"context_id"`
+	ContextName                string               `json:"context_name"`
+	SendNotification           uint8                `json:"send_notification"`
+	SendNotificationName       string               `json:"send_notification_name"`
+	ReleaseCall                uint8                `json:"release_call"`
+	ReleaseCallName            string               `json:"release_call_name"`
+	SequenceIndicator          uint8                `json:"sequence_indicator"`
+	SequenceIndicatorName      string               `json:"sequence_indicator_name"`
+	SegmentationIndicator      uint8                `json:"segmentation_indicator"` // Segments still to follow
+	SegmentationLocalReference *uint8               `json:"segmentation_local_reference,omitempty"`
+	Data                       string               `json:"data,omitempty"`     // Hex, encapsulated application information
+	Segments                   int                  `json:"segments,omitempty"` // Set on the segment completing a reassembly
+	BAT                        *BATInformation      `json:"bat,omitempty"`
+	Charging                   *ChargingInformation `json:"charging,omitempty"`
+	payload                    []byte
+}
+
+// CRGParameters struct
+type CRGParameters struct {
+	parameterList
+
+	ChargedPartyIdentification string                  `json:"charged_party_identification,omitempty"` // Hex
+	ApplicationTransport       []*ApplicationTransport `json:"application_transport,omitempty"`
+	National                   string                  `json:"national,omitempty"` // Hex, charge information in a national format
+	// Billing (ANSI)
+	ChargeNumber               *NumberInfoCharge           `json:"charge_number,omitempty"`
+	OriginatingLineInformation *OriginatingLineInformation `json:"originating_line_information,omitempty"`
+	Jurisdiction               *string                     `json:"jurisdiction,omitempty"`
+}
+
+// ChargingInformation holds a charging message of the Charging ASE (ETSI ES 201 296)
+type ChargingInformation struct {
+	MessageType               uint8         `json:"message_type"`
+	MessageTypeName           string        `json:"message_type_name"`
+	ControlIndicators         []string      `json:"control_indicators,omitempty"`
+	ReferenceIdentification   string        `json:"reference_identification,omitempty"` // Hex
+	Currency                  string        `json:"currency,omitempty"`
+	CurrentTariff             *Tariff       `json:"current_tariff,omitempty"`
+	NextTariff                *Tariff       `json:"next_tariff,omitempty"`     // Applied after the tariff switch
+	SwitchoverTime            string        `json:"switchover_time,omitempty"` // HH:MM
+	AddOnCharge               *TariffCharge `json:"add_on_charge,omitempty"`
+	AcknowledgementIndicators []string      `json:"acknowledgement_indicators,omitempty"`
+}
+
+type Tariff struct {
+	Kind                 string         `json:"kind"` // currency or pulse
+	CommunicationCharges []TariffCharge `json:"communication_charges,omitempty"`
+	CallAttemptCharge    *TariffCharge  `json:"call_attempt_charge,omitempty"`
+	CallSetupCharge      *TariffCharge  `json:"call_setup_charge,omitempty"`
+	NonCyclic            bool           `json:"non_cyclic"`
+}
+
+type TariffCharge struct {
+	CurrencyFactor         *int64 `json:"currency_factor,omitempty"`
+	CurrencyScale          *int64 `json:"currency_scale,omitempty"`
+	Amount                 string `json:"amount,omitempty"` // Factor x 10^scale
+	PulseUnits             *int64 `json:"pulse_units,omitempty"`
+	ChargeUnitTimeInterval *int64 `json:"charge_unit_time_interval,omitempty"`
+	TariffDuration         *int64 `json:"tariff_duration,omitempty"`
+}
+
+// BATInformation holds the BAT ASE information elements (ITU-T Q.765.5)
+type BATInformation struct {
+	ActionIndicator *BATAction   `json:"action_indicator,omitempty"`
+	BNCID           string       `json:"bnc_id,omitempty"` // Hex
+	CodecList       []Codec      `json:"codec_list,omitempty"`
+	SingleCodec     *Codec       `json:"single_codec,omitempty"`
+	Others          []BATElement `json:"others,omitempty"`
+}
+
+type BATAction struct {
+	Num  uint8  `json:"num"`
+	Name string `json:"name"`
+}
+
+type Codec struct {
+	Organization     uint8  `json:"organization"`
+	OrganizationName string `json:"organization_name"`
+	Type             uint8  `json:"type"`
+	Name             string `json:"name"`
+	Configuration    string `json:"configuration,omitempty"` // Hex
+}
+
+type BATElement struct {
+	Identifier uint8  `json:"identifier"`
+	Name       string `json:"name"`
+	Content    string `json:"content"` // Hex
+}
+
 // ISUP Message
 type ISUPMessage struct {
-	MessageType uint8          `json:"message_type"`
-	MessageName string         `json:"message_name,omitempty"`
-	CIC         uint16         `json:"cic"`
-	Data        []byte         `json:"-"`
-	IAM         *IAMParameters `json:"iam,omitempty"` // IAM-specific parameters
+	MessageType   uint8                    `json:"message_type"`
+	MessageName   string                   `json:"message_name,omitempty"`
+	CIC           uint16                   `json:"cic"`
+	Data          []byte                   `json:"-"`
+	Parameters    []*Parameter             `json:"parameters,omitempty"`     // Every parameter as carried, decoded or not
+	Undecoded     string                   `json:"undecoded,omitempty"`      // Hex, message body whose layout is unknown
+	IAM           *IAMParameters           `json:"iam,omitempty"`            // IAM-specific parameters
+	CircuitGroup  *CircuitGroupParameters  `json:"circuit_group,omitempty"`  // Circuit group supervision parameters
+	SuspendResume *SuspendResumeParameters `json:"suspend_resume,omitempty"` // SUS/RES parameters
+	INR           *INRParameters           `json:"inr,omitempty"`            // INR-specific parameters
+	INF           *INFParameters           `json:"inf,omitempty"`            // INF-specific parameters
+	COT           *COTParameters           `json:"cot,omitempty"`            // COT-specific parameters
+	Facility      *FacilityParameters      `json:"facility,omitempty"`       // FAC/FAR/FAA/FRJ parameters
+	ACM           *ACMParameters           `json:"acm,omitempty"`            // ACM-specific parameters
+	ANM           *ANMParameters           `json:"anm,omitempty"`            // ANM-specific parameters
+	REL           *RELParameters           `json:"rel,omitempty"`            // REL-specific parameters
+	USR           *USRParameters           `json:"usr,omitempty"`            // USR-specific parameters
+	APT           *APTParameters           `json:"apt,omitempty"`            // APT-specific parameters
+	SEG           *SEGParameters           `json:"seg,omitempty"`            // SEG-specific parameters
+	IDR           *IDRParameters           `json:"idr,omitempty"`            // IDR-specific parameters
+	IDS           *IDSParameters           `json:"ids,omitempty"`            // IDS-specific parameters
+	CRG           *CRGParameters           `json:"crg,omitempty"`            // CRG-specific parameters
+	PAM           *ISUPMessage             `json:"pam,omitempty"`            // Message embedded in a PAM
+	CFN           *CFNParameters           `json:"cfn,omitempty"`            // CFN-specific parameters
+	CPG           *CPGParameters           `json:"cpg,omitempty"`            // CPG-specific parameters
+	CUG           *CUGParameters           `json:"cug,omitempty"`            // CSVQ/CSVR parameters
+	Segmented     bool                     `json:"segmented,omitempty"`      // Completed by the parameters of a SEG
+
+	variant Variant
+}
+
+// Parameter is one parameter of a message as carried on the wire
+type Parameter struct {
+	Code    uint8  `json:"code"`
+	Name    string `json:"name"`
+	Length  int    `json:"length"`
+	Data    string `json:"data"`              // Hex
+	Decoded string `json:"decoded,omitempty"` // Path of the structured form in the message, e.g. iam.called_party_number
+}
+
+// ISUP variant, selects the CIC width and variant-specific parameter layouts
+type Variant uint8
+
+const (
+	VariantITU  Variant = iota // ITU-T Q.763, 12-bit CIC
+	VariantANSI                // ANSI T1.113, 14-bit CIC
+	VariantETSI                // ETSI EN 300 356, ITU-T format with the ETSI national options
+)
+
+// CICMask returns the mask covering the CIC bits of the variant
+func (v Variant) CICMask() uint16 {
+	if v == VariantANSI {
+		return 0x3FFF
+	}
+	return 0x0FFF
+}
+
+// Variant returns the ISUP variant the message was decoded with
+func (msg *ISUPMessage) Variant() Variant {
+	return msg.variant
 }
 
 // Parse ISUP ITU message
 func ParseISUP_ITU(data []byte) (*ISUPMessage, error) {
+	return parseISUP_ITUFormat(data, VariantITU)
+}
+
+// Parse ISUP ETSI message, ITU-T format with the ETSI national options
+func ParseISUP_ETSI(data []byte) (*ISUPMessage, error) {
+	return parseISUP_ITUFormat(data, VariantETSI)
+}
+
+// Parse a message in the ITU-T format (12-bit CIC)
+func parseISUP_ITUFormat(data []byte, variant Variant) (*ISUPMessage, error) {
 
 	Len := uint32(len(data))
 
@@ -195,13 +964,8 @@ func ParseISUP_ITU(data []byte) (*ISUPMessage, error) {
 
 	Len += 3 // CIC (2 bytes) + Message Type (1 byte)
 
-	// Parse IAM-specific parameters if message type is IAM
-	if ISUPmsg.MessageType == ISUPMessageTypeIAM {
-		iamParams, err := ParseIAM(ISUPmsg.Data)
-		if err == nil {
-			ISUPmsg.IAM = iamParams
-		}
-	}
+	// Parse message-specific parameters
+	decodeMessage(ISUPmsg, variant)
 
 	return ISUPmsg, nil
 }
@@ -232,15 +996,127 @@ func ParseISUP_ANSI(data []byte) (*ISUPMessage, error) {
 
 	Len += 3 // CIC (2 bytes) + Message Type (1 byte)
 
-	// Parse IAM-specific parameters if message type is IAM
-	if ISUPmsg.MessageType == ISUPMessageTypeIAM {
-		iamParams, err := ParseIAM(ISUPmsg.Data)
+	// Parse message-specific parameters
+	decodeMessage(ISUPmsg, VariantANSI)
+
+	return ISUPmsg, nil
+}
+
+// Decode the parameters of the message types we know about
+func decodeMessage(ISUPmsg *ISUPMessage, variant Variant) {
+	ISUPmsg.variant = variant
+	defer listParameters(ISUPmsg)
+
+	switch ISUPmsg.MessageType {
+	case ISUPMessageTypeIAM:
+		iamParams, err := ParseIAM(ISUPmsg.Data, variant)
 		if err == nil {
 			ISUPmsg.IAM = iamParams
 		}
+	case ISUPMessageTypeGRS, ISUPMessageTypeGRA,
+		ISUPMessageTypeCGB, ISUPMessageTypeCGBA,
+		ISUPMessageTypeCGU, ISUPMessageTypeCGUA,
+		ISUPMessageTypeCQM, ISUPMessageTypeCQR:
+		cgParams, err := ParseCircuitGroup(ISUPmsg.MessageType, ISUPmsg.CIC, ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.CircuitGroup = cgParams
+		}
+	case ISUPMessageTypeSUS, ISUPMessageTypeRES:
+		srParams, err := ParseSuspendResume(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.SuspendResume = srParams
+		}
+	case ISUPMessageTypeINR:
+		inrParams, err := ParseINR(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.INR = inrParams
+		}
+	case ISUPMessageTypeINF:
+		infParams, err := ParseINF(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.INF = infParams
+		}
+	case ISUPMessageTypeCOT:
+		cotParams, err := ParseCOT(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.COT = cotParams
+		}
+	case ISUPMessageTypeFAC, ISUPMessageTypeFAR, ISUPMessageTypeFAA, ISUPMessageTypeFRJ:
+		facParams, err := ParseFacility(ISUPmsg.MessageType, ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.Facility = facParams
+		}
+	case ISUPMessageTypeACM:
+		acmParams, err := ParseACM(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.ACM = acmParams
+		}
+	case ISUPMessageTypeANM:
+		anmParams, err := ParseANM(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.ANM = anmParams
+		}
+	case ISUPMessageTypeREL:
+		relParams, err := ParseREL(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.REL = relParams
+		}
+	case ISUPMessageTypeUSR:
+		usrParams, err := ParseUSR(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.USR = usrParams
+		}
+	case ISUPMessageTypeAPT:
+		aptParams, err := ParseAPT(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.APT = aptParams
+		}
+	case ISUPMessageTypeIDR:
+		idrParams, err := ParseIDR(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.IDR = idrParams
+		}
+	case ISUPMessageTypeIDS:
+		idsParams, err := ParseIDS(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.IDS = idsParams
+		}
+	case ISUPMessageTypeCRG:
+		crgParams, err := ParseCRG(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.CRG = crgParams
+		}
+	case ISUPMessageTypePAM:
+		embedded, err := ParsePAM(ISUPmsg.CIC, ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.PAM = embedded
+		}
+	case ISUPMessageTypeCFN:
+		cfnParams, err := ParseCFN(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.CFN = cfnParams
+		}
+	case ISUPMessageTypeCPG:
+		cpgParams, err := ParseCPG(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.CPG = cpgParams
+		}
+	case ISUPMessageTypeCSVQ:
+		cugParams, err := ParseCSVQ(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.CUG = cugParams
+		}
+	case ISUPMessageTypeCSVR:
+		cugParams, err := ParseCSVR(ISUPmsg.Data, variant)
+		if err == nil {
+			ISUPmsg.CUG = cugParams
+		}
+	case ISUPMessageTypeSEG:
+		segParams, err := ParseSEG(ISUPmsg.Data)
+		if err == nil {
+			ISUPmsg.SEG = segParams
+		}
 	}
-
-	return ISUPmsg, nil
 }
 
 // ISUP message type constants
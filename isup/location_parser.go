@@ -0,0 +1,168 @@
+package isup
+
+import (
+	"encoding/hex"
+	"math"
+)
+
+// Types of shape of a Calling Geodetic Location (ITU-T Q.763 3.88)
+const (
+	ShapeEllipsoidPoint                  = 0x00
+	ShapeEllipsoidPointWithUncertainty   = 0x01
+	ShapePointWithAltitudeAndUncertainty = 0x02
+	ShapeEllipseOnTheEllipsoid           = 0x03
+	ShapeEllipsoidCircleSector           = 0x04
+	ShapePolygon                         = 0x05
+)
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Location Number parameter (ITU-T Q.763 3.30)
+func parseNumberInfoLocation(data []byte) *NumberInfoLocation {
+
+	Len := len(data)
+
+	if Len < 2 {
+		return nil
+	}
+
+	info := &NumberInfoLocation{}
+
+	info.TON = data[0] & 0x7F
+	info.TONName = natureOfAddressValues[info.TON]
+	info.INN = (data[1] >> 7) & 0x01
+	info.INNName = innValues[info.INN]
+	info.NPI = (data[1] >> 4) & 0x07
+	info.NPIName = npiValues[info.NPI]
+	info.Restrict = (data[1] >> 2) & 0x03
+	info.RestrictName = restrictValues[info.Restrict]
+	info.Screened = data[1] & 0x03
+	info.ScreenedName = screenedValues[info.Screened]
+
+	// Extract address digits
+	if Len > 2 {
+		info.Number = decodeBCDAddress(data[2:], (data[0]>>7)&0x01 == 1)
+	}
+
+	return info
+}
+
+// Parse a Calling Geodetic Location parameter (ITU-T Q.763 3.88)
+func parseCallingGeodeticLocation(data []byte) *CallingGeodeticLocation {
+
+	Len := len(data)
+
+	if Len < 2 {
+		return nil
+	}
+
+	loc := &CallingGeodeticLocation{
+		Restrict: (data[0] >> 2) & 0x03,
+		Screened: data[0] & 0x03,
+		Shape:    data[1] & 0x7F,
+	}
+	loc.RestrictName = restrictValues[loc.Restrict]
+	loc.ScreenedName = screenedValues[loc.Screened]
+	loc.ShapeName = geodeticShapeValues[loc.Shape]
+
+	desc := data[2:]
+	switch loc.Shape {
+	case ShapeEllipsoidPoint:
+		loc.Points = parseGeodeticPoints(desc, 1)
+	case ShapeEllipsoidPointWithUncertainty:
+		loc.Points = parseGeodeticPoints(desc, 1)
+		if len(desc) >= 8 {
+			uncertainty := geodeticUncertainty(desc[6])
+			loc.UncertaintyMeters = &uncertainty
+			confidence := desc[7] & 0x7F
+			loc.Confidence = &confidence
+		}
+	case ShapePointWithAltitudeAndUncertainty:
+		loc.Points = parseGeodeticPoints(desc, 1)
+		if len(desc) >= 11 {
+			altitude := int(desc[6]&0x7F)<<8 | int(desc[7])
+			if desc[6]&0x80 != 0 {
+				altitude = -altitude // Depth
+			}
+			loc.AltitudeMeters = &altitude
+			uncertainty := geodeticUncertainty(desc[8])
+			loc.UncertaintyMeters = &uncertainty
+			altitudeUncertainty := geodeticAltitudeUncertainty(desc[9])
+			loc.AltitudeUncertaintyMeters = &altitudeUncertainty
+			confidence := desc[10] & 0x7F
+			loc.Confidence = &confidence
+		}
+	case ShapeEllipseOnTheEllipsoid:
+		loc.Points = parseGeodeticPoints(desc, 1)
+		if len(desc) >= 10 {
+			major := geodeticUncertainty(desc[6])
+			minor := geodeticUncertainty(desc[7])
+			orientation := int(desc[8]&0x7F) * 2
+			confidence := desc[9] & 0x7F
+			loc.UncertaintyMeters = &major
+			loc.UncertaintyMinorMeters = &minor
+			loc.OrientationDegrees = &orientation
+			loc.Confidence = &confidence
+		}
+	case ShapeEllipsoidCircleSector:
+		loc.Points = parseGeodeticPoints(desc, 1)
+		if len(desc) >= 10 {
+			radius := geodeticUncertainty(desc[6])
+			offset := int(desc[7]) * 2
+			included := int(desc[8]) * 2
+			confidence := desc[9] & 0x7F
+			loc.UncertaintyMeters = &radius
+			loc.OrientationDegrees = &offset
+			loc.IncludedAngleDegrees = &included
+			loc.Confidence = &confidence
+		}
+	case ShapePolygon:
+		if len(desc) >= 1 {
+			loc.Points = parseGeodeticPoints(desc[1:], int(desc[0]&0x0F))
+		}
+	default:
+		loc.Description = hex.EncodeToString(desc)
+	}
+
+	return loc
+}
+
+// Decode consecutive latitude/longitude pairs (3 octets each, as in 3GPP TS 23.032)
+func parseGeodeticPoints(data []byte, count int) []GeodeticPoint {
+	var points []GeodeticPoint
+
+	for i := 0; i < count && len(data) >= (i+1)*6; i++ {
+		p := data[i*6 : i*6+6]
+
+		// Latitude: sign bit and 23 bits of degrees, scaled to 90
+		lat := float64(int(p[0]&0x7F)<<16|int(p[1])<<8|int(p[2])) * 90 / (1 << 23)
+		if p[0]&0x80 != 0 {
+			lat = -lat
+		}
+
+		// Longitude: 24 bits two's complement, scaled to 360
+		raw := int32(uint32(p[3])<<24|uint32(p[4])<<16|uint32(p[5])<<8) >> 8
+		lon := float64(raw) * 360 / (1 << 24)
+
+		points = append(points, GeodeticPoint{
+			Latitude:  math.Round(lat*1e6) / 1e6,
+			Longitude: math.Round(lon*1e6) / 1e6,
+		})
+	}
+
+	return points
+}
+
+// Uncertainty code k to meters: 10 x (1.1^k - 1)
+func geodeticUncertainty(code uint8) float64 {
+	r := 10 * (math.Pow(1.1, float64(code&0x7F)) - 1)
+	return math.Round(r*10) / 10
+}
+
+// Altitude uncertainty code k to meters: 45 x (1.025^k - 1)
+func geodeticAltitudeUncertainty(code uint8) float64 {
+	r := 45 * (math.Pow(1.025, float64(code&0x7F)) - 1)
+	return math.Round(r*10) / 10
+}
@@ -0,0 +1,84 @@
+package isup
+
+// ParseIDR decodes an Identification Request message according to ITU-T Q.763
+func ParseIDR(data []byte) (*IDRParameters, error) {
+	idr := &IDRParameters{}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := idr.add(code, val)
+		switch code {
+		case ISUPMCIDRequestIndicators:
+			idr.Indicators = parseMCIDRequestIndicators(val)
+			idr.decoded(param, "idr.indicators", idr.Indicators != nil)
+		}
+	})
+
+	return idr, nil
+}
+
+// ParseIDS decodes an Identification Response message according to ITU-T Q.763
+func ParseIDS(data []byte) (*IDSParameters, error) {
+	ids := &IDSParameters{}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := ids.add(code, val)
+		switch code {
+		case ISUPMCIDResponseIndicators:
+			ids.Indicators = parseMCIDResponseIndicators(val)
+			ids.decoded(param, "ids.indicators", ids.Indicators != nil)
+		case ISUPCallingPartyNumber:
+			ids.CallingPartyNumber = parseNumberInfoCalling(val)
+			ids.decoded(param, "ids.calling_party_number", ids.CallingPartyNumber != nil)
+		case ISUPGenericNumber:
+			ids.GenericNumber = parseNumberInfoGeneric(val)
+			ids.decoded(param, "ids.generic_number", ids.GenericNumber != nil)
+		case ISUPAccessTransport:
+			ids.AccessTransport = parseAccessTransport(val)
+			ids.decoded(param, "ids.access_transport", ids.AccessTransport != nil)
+		}
+	})
+
+	return ids, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseMCIDRequestIndicators(data []byte) *MCIDRequestIndicators {
+	if len(data) < 1 {
+		return nil
+	}
+	octet := data[0]
+
+	ind := &MCIDRequestIndicators{
+		MCID:    octet & 0x01,
+		Holding: (octet >> 1) & 0x01,
+	}
+	ind.MCIDName = requestedValues[ind.MCID]
+	ind.HoldingName = requestedValues[ind.Holding]
+
+	return ind
+}
+
+func parseMCIDResponseIndicators(data []byte) *MCIDResponseIndicators {
+	if len(data) < 1 {
+		return nil
+	}
+	octet := data[0]
+
+	ind := &MCIDResponseIndicators{
+		MCID:         octet & 0x01,
+		HoldProvided: (octet >> 1) & 0x01,
+	}
+	ind.MCIDName = includedValues[ind.MCID]
+	ind.HoldProvidedName = holdProvidedValues[ind.HoldProvided]
+
+	return ind
+}
@@ -0,0 +1,189 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+/**
+** Helpers shared by the message parsers to walk the Q.763 message layout:
+** fixed mandatory part, pointers to the variable mandatory part, optional part
+**/
+
+// Return the variable mandatory parameter referenced by the pointer at ptrPos
+func readVariableParameter(data []byte, ptrPos int) ([]byte, error) {
+	Len := len(data)
+
+	if ptrPos >= Len {
+		return nil, fmt.Errorf("missing pointer at offset %d", ptrPos)
+	}
+	ptr := int(data[ptrPos])
+	if ptr == 0 {
+		return nil, fmt.Errorf("empty pointer at offset %d", ptrPos)
+	}
+	base := ptrPos + ptr
+	if base+1 > Len {
+		return nil, fmt.Errorf("pointer at offset %d out of range", ptrPos)
+	}
+	l := int(data[base])
+	if base+1+l > Len {
+		return nil, fmt.Errorf("parameter at offset %d truncated", base)
+	}
+
+	return data[base+1 : base+1+l], nil
+}
+
+// Tell whether the pointer at ptrPos references a complete optional part, closed by the end of
+// optional parameters
+func isOptionalPart(data []byte, ptrPos int) bool {
+	Len := len(data)
+
+	if ptrPos >= Len || data[ptrPos] == 0 {
+		return false
+	}
+	offset := ptrPos + int(data[ptrPos])
+	for offset < Len {
+		if data[offset] == ISUPEndOfOptionalParameters {
+			return offset+1 == Len
+		}
+		if offset+1 >= Len {
+			return false
+		}
+		offset += 2 + int(data[offset+1])
+	}
+
+	return false
+}
+
+// Walk the optional part referenced by the pointer at ptrPos, calling fn for each parameter
+func forEachOptionalParameter(data []byte, ptrPos int, fn func(code uint8, val []byte)) {
+	Len := len(data)
+
+	if ptrPos >= Len || data[ptrPos] == 0 {
+		return
+	}
+	offset := ptrPos + int(data[ptrPos])
+	for offset < Len {
+		t := data[offset]
+		offset++
+		if t == ISUPEndOfOptionalParameters {
+			break
+		}
+		if offset >= Len {
+			break
+		}
+		l := int(data[offset])
+		offset++
+		if offset+l > Len {
+			break
+		}
+		fn(t, data[offset:offset+l])
+		offset += l
+	}
+}
+
+// Parameters of a message as its parser walked them, in message order
+type parameterList struct {
+	params []*Parameter
+}
+
+// Add a parameter as carried in the message
+func (l *parameterList) add(code uint8, val []byte) *Parameter {
+	param := &Parameter{
+		Code:   code,
+		Name:   GetParameterName(code),
+		Length: len(val),
+		Data:   hex.EncodeToString(val),
+	}
+	l.params = append(l.params, param)
+	return param
+}
+
+// Point a parameter to the field it was decoded into, e.g. iam.called_party_number, when it was.
+// A later parameter decoded into the same field replaces the earlier one, which no longer points to it.
+func (l *parameterList) decoded(param *Parameter, path string, decoded bool) {
+	if !decoded {
+		return
+	}
+	for _, p := range l.params {
+		if p.Decoded == path {
+			p.Decoded = ""
+		}
+	}
+	param.Decoded = path
+}
+
+// Path of the element appended last to a decoded list of n elements, e.g. iam.generic_digits[1]
+func elementPath(list string, n int) string {
+	return fmt.Sprintf("%s[%d]", list, n-1)
+}
+
+// List every parameter of the message as its parser walked it, pointing to its decoded form when
+// there is one. The body of a message without a parser, or that its parser rejected, is kept raw.
+func listParameters(msg *ISUPMessage) {
+	msg.Parameters = nil
+	msg.Undecoded = ""
+
+	switch {
+	case msg.MessageType == ISUPMessageTypePAM:
+		// The embedded message lists its own parameters
+		return
+	case msg.MessageType == ISUPMessageTypeCRG && msg.CRG != nil && msg.CRG.National != "":
+		// Already kept raw as national charge information
+		return
+	}
+
+	list := msg.parameterList()
+	if list == nil {
+		if len(msg.Data) > 0 {
+			msg.Undecoded = hex.EncodeToString(msg.Data)
+		}
+		return
+	}
+	msg.Parameters = list.params
+}
+
+// Return the parameters walked by the parser of the message, nil when it was not decoded
+func (msg *ISUPMessage) parameterList() *parameterList {
+	switch {
+	case msg.IAM != nil:
+		return &msg.IAM.parameterList
+	case msg.CircuitGroup != nil:
+		return &msg.CircuitGroup.parameterList
+	case msg.SuspendResume != nil:
+		return &msg.SuspendResume.parameterList
+	case msg.INR != nil:
+		return &msg.INR.parameterList
+	case msg.INF != nil:
+		return &msg.INF.parameterList
+	case msg.COT != nil:
+		return &msg.COT.parameterList
+	case msg.Facility != nil:
+		return &msg.Facility.parameterList
+	case msg.ACM != nil:
+		return &msg.ACM.parameterList
+	case msg.ANM != nil:
+		return &msg.ANM.parameterList
+	case msg.REL != nil:
+		return &msg.REL.parameterList
+	case msg.USR != nil:
+		return &msg.USR.parameterList
+	case msg.APT != nil:
+		return &msg.APT.parameterList
+	case msg.SEG != nil:
+		return &msg.SEG.parameterList
+	case msg.IDR != nil:
+		return &msg.IDR.parameterList
+	case msg.IDS != nil:
+		return &msg.IDS.parameterList
+	case msg.CRG != nil:
+		return &msg.CRG.parameterList
+	case msg.CFN != nil:
+		return &msg.CFN.parameterList
+	case msg.CPG != nil:
+		return &msg.CPG.parameterList
+	case msg.CUG != nil:
+		return &msg.CUG.parameterList
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParsePAM decodes a Pass Along message according to ITU-T Q.763: the embedded
+// message (type code and parameters) is decoded as a message on the same circuit
+func ParsePAM(cic uint16, data []byte, variant Variant) (*ISUPMessage, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("PAM missing embedded message type")
+	}
+
+	embedded := &ISUPMessage{
+		CIC:         cic,
+		MessageType: data[0],
+		MessageName: GetISUPMessageTypeName(data[0]),
+		Data:        data[1:],
+	}
+	decodeMessage(embedded, variant)
+
+	return embedded, nil
+}
@@ -0,0 +1,82 @@
+package isup
+
+// Pivot routing indicators (ITU-T Q.763 3.85)
+const (
+	PivotRequest        = 0x01
+	PivotCancelRequest  = 0x02
+	PivotRequestFailure = 0x03
+)
+
+// Cause of a release asking the previous exchange to query the number portability database (ITU-T Q.850)
+const CauseQoRPortedNumber = 14
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Pivot Capability parameter (ITU-T Q.763 3.84)
+func parsePivotCapability(data []byte) *PivotCapability {
+	if len(data) < 1 {
+		return nil
+	}
+
+	capability := &PivotCapability{
+		Capability:                data[0] & 0x07,
+		InterworkingToRedirection: (data[0] >> 6) & 0x01,
+	}
+	capability.CapabilityName = pivotPossibleValues[capability.Capability]
+	capability.InterworkingToRedirectionName = interworkingToRedirectionValues[capability.InterworkingToRedirection]
+
+	return capability
+}
+
+// Parse a Pivot Routing Indicators parameter (ITU-T Q.763 3.85)
+func parsePivotRoutingIndicators(data []byte) *PivotRoutingIndicators {
+	if len(data) < 1 {
+		return nil
+	}
+
+	indicators := &PivotRoutingIndicators{
+		Indicator: data[0] & 0x7F,
+	}
+	indicators.IndicatorName = pivotRoutingIndicatorValues[indicators.Indicator]
+
+	return indicators
+}
+
+// Parse a Pivot Status parameter (ITU-T Q.763 3.92)
+func parsePivotStatus(data []byte) *PivotStatus {
+	if len(data) < 1 {
+		return nil
+	}
+
+	status := &PivotStatus{
+		Status: data[0] & 0x03,
+	}
+	status.StatusName = pivotStatusValues[status.Status]
+
+	return status
+}
+
+// Parse a Pivot Counter parameter (ITU-T Q.763 3.93)
+func parsePivotCounter(data []byte) *uint8 {
+	if len(data) < 1 {
+		return nil
+	}
+	counter := data[0] & 0x1F
+	return &counter
+}
+
+// Parse a QoR Capability parameter (ITU-T Q.763 3.91)
+func parseQoRCapability(data []byte) *QoRCapability {
+	if len(data) < 1 {
+		return nil
+	}
+
+	capability := &QoRCapability{
+		Capability: data[0] & 0x01,
+	}
+	capability.CapabilityName = qorCapabilityValues[capability.Capability]
+
+	return capability
+}
@@ -0,0 +1,86 @@
+package isup
+
+// Number portability status indicator (ITU-T Q.763 3.101)
+const (
+	PortabilityNoIndication     = 0x00
+	PortabilityQueryNotDone     = 0x01
+	PortabilityQueryDoneNotPort = 0x02
+	PortabilityQueryDonePorted  = 0x03
+)
+
+// Type of address of an ANSI Generic Address carrying the number dialled before translation
+const AddressTypePortedNumber = 0xC0
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse a Network Routing Number parameter (ITU-T Q.763 3.90)
+func parseNetworkRoutingNumber(data []byte) *NetworkRoutingNumber {
+	if len(data) < 1 {
+		return nil
+	}
+
+	nrn := &NetworkRoutingNumber{
+		NPI: (data[0] >> 4) & 0x07,
+		TON: data[0] & 0x0F,
+	}
+	nrn.NPIName = npiValues[nrn.NPI]
+	nrn.TONName = networkRoutingNumberNatureValues[nrn.TON]
+
+	// Extract address digits
+	if len(data) > 1 {
+		nrn.Number = decodeBCDAddress(data[1:], (data[0]>>7)&0x01 == 1)
+	}
+
+	return nrn
+}
+
+// Parse a Number Portability Forward Information parameter (ITU-T Q.763 3.101)
+func parseNumberPortabilityForwardInformation(data []byte) *NumberPortabilityForwardInformation {
+	if len(data) < 1 {
+		return nil
+	}
+
+	npfi := &NumberPortabilityForwardInformation{
+		Status: data[0] & 0x0F,
+	}
+	npfi.StatusName = portabilityStatusValues[npfi.Status]
+
+	return npfi
+}
+
+// Parse an ANSI Generic Address parameter (ANSI T1.113), sharing its code with the ITU-T Generic Number
+func parseGenericAddress(data []byte) *GenericAddress {
+	if len(data) < 3 {
+		return nil
+	}
+
+	address := &GenericAddress{
+		Type: data[0],
+		TON:  data[1] & 0x7F,
+		NPI:  (data[2] >> 4) & 0x07,
+	}
+	address.TypeName = addressTypeValues[address.Type]
+	address.TONName = natureOfAddressValues[address.TON]
+	address.NPIName = npiValues[address.NPI]
+	address.Restrict = (data[2] >> 2) & 0x03
+	address.RestrictName = restrictValues[address.Restrict]
+
+	// Extract address digits
+	if len(data) > 3 {
+		address.Number = decodeBCDAddress(data[3:], (data[1]>>7)&0x01 == 1)
+	}
+
+	return address
+}
+
+// PortedNumber returns the number dialled before an ANSI LRN translation, carried in a Generic Address
+func (iam *IAMParameters) PortedNumber() *GenericAddress {
+	for _, address := range iam.GenericAddresses {
+		if address.Type == AddressTypePortedNumber {
+			return address
+		}
+	}
+	return nil
+}
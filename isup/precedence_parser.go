@@ -0,0 +1,63 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// Precedence levels of the MLPP Precedence parameter (ITU-T Q.763 3.34)
+const (
+	PrecedenceFlashOverride = 0x00
+	PrecedenceFlash         = 0x01
+	PrecedenceImmediate     = 0x02
+	PrecedencePriority      = 0x03
+	PrecedenceRoutine       = 0x04
+)
+
+// Calling party categories asking for preferential treatment
+const (
+	CategoryPriority = 0x0B // Calling subscriber with priority
+	CategoryIEPS     = 0x0E // IEPS call marking for preferential call set up (ITU-T Q.763)
+	CategoryNSEP     = 0xE2 // National security and emergency preparedness (ANSI T1.113)
+)
+
+// Causes of a call pre-empted or blocked for lack of resources (ITU-T Q.850)
+const (
+	CausePreemption                = 8
+	CausePreemptionCircuitReserved = 9
+	CauseNoCircuitAvailable        = 34
+	CauseSwitchingCongestion       = 42
+	CauseRequestedCircuitNotAvail  = 44
+	CausePrecedenceCallBlocked     = 46
+	CauseResourceUnavailable       = 47
+)
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+// Parse an MLPP Precedence parameter (ITU-T Q.763 3.34)
+func parseMLPPPrecedence(data []byte) *MLPPPrecedence {
+	if len(data) < 6 {
+		return nil
+	}
+
+	precedence := &MLPPPrecedence{
+		Level:           data[0] & 0x0F,
+		LFB:             (data[0] >> 5) & 0x03,
+		NetworkIdentity: decodeNetworkIdentity(data[1:3]),
+		ServiceDomain:   uint32(data[3])<<16 | uint32(data[4])<<8 | uint32(data[5]),
+	}
+	precedence.LevelName = precedenceLevelValues[precedence.Level]
+	precedence.LFBName = lookAheadForBusyValues[precedence.LFB]
+
+	return precedence
+}
+
+// Decode network identity digits, two per octet with the first digit in the high nibble
+func decodeNetworkIdentity(data []byte) string {
+	var ni string
+	for _, b := range data {
+		ni += fmt.Sprintf("%X%X", b>>4, b&0x0F)
+	}
+	return ni
+}
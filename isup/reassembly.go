@@ -0,0 +1,91 @@
+package isup
+
+import (
+	"encoding/hex"
+)
+
+// Segmented APM-user information being collected
+type apmKey struct {
+	opc       uint32
+	dpc       uint32
+	cic       uint16
+	contextID uint16
+	slr       uint8
+}
+
+type apmSegments struct {
+	payload []byte
+	count   int
+}
+
+// APMReassembler completes segmented application transport data (ITU-T Q.765)
+type APMReassembler struct {
+	pending map[apmKey]*apmSegments
+}
+
+// NewAPMReassembler creates an APM reassembler
+func NewAPMReassembler() *APMReassembler {
+	return &APMReassembler{
+		pending: make(map[apmKey]*apmSegments),
+	}
+}
+
+// Reassemble collects the APM segments of a message sent from opc to dpc.
+// The segment completing a sequence gets the whole APM-user information.
+func (r *APMReassembler) Reassemble(opc, dpc uint32, msg *ISUPMessage) {
+	if msg == nil {
+		return
+	}
+
+	for _, app := range msg.ApplicationTransports() {
+		if !app.IsSegmented() {
+			continue
+		}
+		key := apmKey{opc: opc, dpc: dpc, cic: msg.CIC, contextID: app.ContextID}
+		if app.SegmentationLocalReference != nil {
+			key.slr = *app.SegmentationLocalReference
+		}
+
+		segments, exists := r.pending[key]
+		if app.SequenceIndicator == 1 {
+			// A new sequence drops any incomplete one
+			segments = &apmSegments{}
+			r.pending[key] = segments
+		} else if !exists {
+			// Subsequent segment without its first segment
+			continue
+		}
+		segments.payload = append(segments.payload, app.payload...)
+		segments.count++
+
+		if app.SegmentationIndicator != 0 {
+			continue
+		}
+		delete(r.pending, key)
+		app.Segments = segments.count
+		app.payload = segments.payload
+		app.Data = hex.EncodeToString(segments.payload)
+		app.decodeInformation()
+	}
+}
+
+// ApplicationTransports returns the application transport parameters carried by a message
+func (msg *ISUPMessage) ApplicationTransports() []*ApplicationTransport {
+	switch {
+	case msg.IAM != nil:
+		return msg.IAM.ApplicationTransport
+	case msg.ACM != nil:
+		return msg.ACM.ApplicationTransport
+	case msg.ANM != nil:
+		return msg.ANM.ApplicationTransport
+	case msg.CPG != nil:
+		return msg.CPG.ApplicationTransport
+	case msg.APT != nil:
+		return msg.APT.ApplicationTransport
+	case msg.CRG != nil:
+		return msg.CRG.ApplicationTransport
+	case msg.PAM != nil:
+		return msg.PAM.ApplicationTransports()
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseREL decodes a Release message according to ITU-T Q.763
+func ParseREL(data []byte) (*RELParameters, error) {
+	rel := &RELParameters{}
+
+	/**
+	** Variable mandatory parameters
+	**/
+	val, err := readVariableParameter(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("missing Cause Indicators: %v", err)
+	}
+	rel.Cause = parseCauseIndicators(val)
+	rel.decoded(rel.add(ISUPCauseIndicators, val), "rel.cause", rel.Cause != nil)
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 1, func(code uint8, val []byte) {
+		param := rel.add(code, val)
+		switch code {
+		case ISUPUserToUserIndicators:
+			rel.UserToUserIndicators = parseUserToUserIndicators(val)
+			rel.decoded(param, "rel.user_to_user_indicators", rel.UserToUserIndicators != nil)
+		case ISUPUserToUserInformation:
+			rel.UserToUserInformation = parseUserToUserInformation(val)
+			rel.decoded(param, "rel.user_to_user_information", rel.UserToUserInformation != nil)
+		case ISUPRedirectionNumber:
+			rel.RedirectionNumber = parseNumberInfoCalled(val)
+			rel.decoded(param, "rel.redirection_number", rel.RedirectionNumber != nil)
+		case ISUPRedirectionInformation:
+			rel.RedirectionInformation = parseRedirectionInformation(val)
+			rel.decoded(param, "rel.redirection_information", rel.RedirectionInformation != nil)
+		case ISUPRedirectCounter:
+			rel.RedirectCounter = parseRedirectCounter(val)
+			rel.decoded(param, "rel.redirect_counter", rel.RedirectCounter != nil)
+		case ISUPRedirectBackwardInformation:
+			rel.RedirectBackwardInformation = parseRedirectInformationItems(val, redirectBackwardInformationTags)
+			rel.decoded(param, "rel.redirect_backward_information", rel.RedirectBackwardInformation != nil)
+		case ISUPMessageCompatibilityInformation:
+			rel.MessageCompatibility = parseMessageCompatibilityInformation(val)
+			rel.decoded(param, "rel.message_compatibility", rel.MessageCompatibility != nil)
+		case ISUPParameterCompatibilityInformation:
+			rel.ParameterCompatibility = parseParameterCompatibilityInformation(val)
+			rel.decoded(param, "rel.parameter_compatibility", rel.ParameterCompatibility != nil)
+		}
+	})
+
+	return rel, nil
+}
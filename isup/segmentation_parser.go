@@ -0,0 +1,102 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// Position of the optional part pointer in the messages that can be followed by a SEG
+var segmentableOptionalPointers = map[uint8]int{
+	ISUPMessageTypeIAM: 6, // After the fixed part and the two variable part pointers, ITU and ANSI alike
+	ISUPMessageTypeACM: 2, // After the Backward Call Indicators
+	ISUPMessageTypeANM: 0,
+	ISUPMessageTypeCON: 2, // After the Backward Call Indicators
+	ISUPMessageTypeCPG: 1, // After the Event Information
+}
+
+// Simple segmentation indicator, bit C of the optional forward and backward call indicators
+const simpleSegmentationIndicator = 0x04
+
+// ParseSEG decodes a Segmentation message according to ITU-T Q.763
+func ParseSEG(data []byte) (*SEGParameters, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("SEG too short")
+	}
+	seg := &SEGParameters{}
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 0, func(code uint8, val []byte) {
+		param := seg.add(code, val)
+		switch code {
+		case ISUPUserToUserInformation:
+			seg.UserToUserInformation = parseUserToUserInformation(val)
+			seg.decoded(param, "seg.user_to_user_information", seg.UserToUserInformation != nil)
+		case ISUPGenericNumber:
+			seg.GenericNumber = parseNumberInfoGeneric(val)
+			seg.decoded(param, "seg.generic_number", seg.GenericNumber != nil)
+		}
+	})
+
+	return seg, nil
+}
+
+// AwaitsSegment tells whether the message announces that a SEG will complete it (ITU-T Q.764 2.1.12)
+func (msg *ISUPMessage) AwaitsSegment() bool {
+	ptrPos, exists := segmentableOptionalPointers[msg.MessageType]
+	if !exists {
+		return false
+	}
+
+	indicators := uint8(ISUPOptionalBackwardCallIndicators)
+	if msg.MessageType == ISUPMessageTypeIAM {
+		indicators = ISUPOptionalForwardCallIndicators
+	}
+
+	awaits := false
+	forEachOptionalParameter(msg.Data, ptrPos, func(code uint8, val []byte) {
+		if code == indicators && len(val) >= 1 && val[0]&simpleSegmentationIndicator != 0 {
+			awaits = true
+		}
+	})
+	return awaits
+}
+
+// MergeSegment appends the optional parameters of a SEG to the message it completes
+// and decodes the message again with the combined parameter set
+func (msg *ISUPMessage) MergeSegment(seg *ISUPMessage) error {
+	if seg.MessageType != ISUPMessageTypeSEG {
+		return fmt.Errorf("%s is not a segmentation message", seg.MessageName)
+	}
+	ptrPos, exists := segmentableOptionalPointers[msg.MessageType]
+	if !exists {
+		return fmt.Errorf("%s can not be segmented", msg.MessageName)
+	}
+	Len := len(msg.Data)
+	if ptrPos >= Len || msg.Data[ptrPos] == 0 {
+		return fmt.Errorf("%s has no optional part", msg.MessageName)
+	}
+
+	// Find the end of optional parameters octet of the first segment
+	end := ptrPos + int(msg.Data[ptrPos])
+	for end < Len && msg.Data[end] != ISUPEndOfOptionalParameters {
+		if end+1 >= Len || end+2+int(msg.Data[end+1]) > Len {
+			return fmt.Errorf("%s optional part truncated", msg.MessageName)
+		}
+		end += 2 + int(msg.Data[end+1])
+	}
+
+	data := make([]byte, 0, end+len(seg.Data))
+	data = append(data, msg.Data[:end]...)
+	forEachOptionalParameter(seg.Data, 0, func(code uint8, val []byte) {
+		data = append(data, code, uint8(len(val)))
+		data = append(data, val...)
+	})
+	data = append(data, ISUPEndOfOptionalParameters)
+
+	msg.Data = data
+	msg.Segmented = true
+	decodeMessage(msg, msg.variant)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package isup
+
+import (
+	"fmt"
+)
+
+// ParseSuspendResume decodes a SUS or RES message according to ITU-T Q.763
+func ParseSuspendResume(data []byte, variant Variant) (*SuspendResumeParameters, error) {
+	Len := len(data)
+	if Len < 1 {
+		return nil, fmt.Errorf("missing Suspend/Resume Indicators")
+	}
+	offset := 0
+	sr := &SuspendResumeParameters{}
+
+	/**
+	** Fixed mandatory parameters
+	**/
+	sr.Indicator = data[offset] & 0x01
+	sr.IndicatorName = suspendResumeIndicators[sr.Indicator]
+	sr.decoded(sr.add(ISUPSuspendResumeIndicators, data[offset:offset+1]), "suspend_resume.indicator", true)
+	offset++
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, offset, func(code uint8, val []byte) {
+		param := sr.add(code, val)
+		switch code {
+		case ISUPCallReference:
+			sr.CallReference = parseCallReference(val, variant)
+			sr.decoded(param, "suspend_resume.call_reference", sr.CallReference != nil)
+		}
+	})
+
+	return sr, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseCallReference(data []byte, variant Variant) *CallReference {
+
+	Len := len(data)
+
+	if Len < 5 {
+		return nil
+	}
+
+	ref := &CallReference{
+		CallIdentity: uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]),
+	}
+
+	// Signalling point code: 14 bits for ITU, 24 bits for ANSI
+	if variant == VariantANSI && Len >= 6 {
+		ref.PointCode = uint32(data[5])<<16 | uint32(data[4])<<8 | uint32(data[3])
+	} else {
+		ref.PointCode = (uint32(data[4]&0x3F)<<8 | uint32(data[3]))
+	}
+
+	return ref
+}
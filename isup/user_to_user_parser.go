@@ -0,0 +1,117 @@
+package isup
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// ParseUSR decodes a User-to-User Information message according to ITU-T Q.763
+func ParseUSR(data []byte, variant Variant) (*USRParameters, error) {
+	usr := &USRParameters{}
+
+	/**
+	** Variable mandatory parameters
+	**/
+	val, err := readVariableParameter(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("missing User-to-User Information: %v", err)
+	}
+	usr.UserToUserInformation = parseUserToUserInformation(val)
+	usr.decoded(usr.add(ISUPUserToUserInformation, val), "usr.user_to_user_information", usr.UserToUserInformation != nil)
+
+	/**
+	** Optional parameters
+	**/
+	forEachOptionalParameter(data, 1, func(code uint8, val []byte) {
+		param := usr.add(code, val)
+		switch code {
+		case ISUPCallReference:
+			usr.CallReference = parseCallReference(val, variant)
+			usr.decoded(param, "usr.call_reference", usr.CallReference != nil)
+		}
+	})
+
+	return usr, nil
+}
+
+/**
+** Helper functions to parse individual parameters
+**/
+
+func parseUserToUserIndicators(data []byte) *UserToUserIndicators {
+
+	Len := len(data)
+
+	if Len < 1 {
+		return nil
+	}
+
+	octet := data[0]
+	ind := &UserToUserIndicators{
+		Type:           octet & 0x01,
+		Service1:       (octet >> 1) & 0x03,
+		Service2:       (octet >> 3) & 0x03,
+		Service3:       (octet >> 5) & 0x03,
+		NetworkDiscard: (octet >> 7) & 0x01,
+	}
+	ind.TypeName = uuIndicatorTypeValues[ind.Type]
+
+	services := uuServiceRequestValues
+	if ind.Type == 0x01 {
+		services = uuServiceResponseValues
+	}
+	ind.Service1Name = services[ind.Service1]
+	ind.Service2Name = services[ind.Service2]
+	ind.Service3Name = services[ind.Service3]
+	ind.NetworkDiscardName = uuNetworkDiscardValues[ind.NetworkDiscard]
+
+	return ind
+}
+
+func parseUserToUserInformation(data []byte) *UserToUserInformation {
+
+	Len := len(data)
+
+	if Len < 1 {
+		return nil
+	}
+
+	uui := &UserToUserInformation{
+		ProtocolDiscriminator:     data[0],
+		ProtocolDiscriminatorName: getProtocolDiscriminatorName(data[0]),
+	}
+
+	// IA5 payloads are rendered as text, anything else as hex
+	if uui.ProtocolDiscriminator == 0x04 {
+		uui.Text = decodeIA5(data[1:])
+	} else {
+		uui.Data = hex.EncodeToString(data[1:])
+	}
+
+	return uui
+}
+
+func getProtocolDiscriminatorName(pd uint8) string {
+	if name, exists := protocolDiscriminatorValues[pd]; exists {
+		return name
+	}
+	switch {
+	case pd >= 0x10 && pd <= 0x3F, pd >= 0x50 && pd <= 0xFE:
+		return "reserved for other network layer or layer 3 protocols, including X.25"
+	case pd >= 0x40 && pd <= 0x4F:
+		return "national use"
+	}
+	return "reserved"
+}
+
+// Helper function to decode IA5 characters, dropping non-printable ones
+func decodeIA5(data []byte) string {
+	text := make([]byte, 0, len(data))
+	for _, b := range data {
+		b &= 0x7F
+		if b >= 0x20 && b < 0x7F {
+			text = append(text, b)
+		}
+	}
+	return string(text)
+}
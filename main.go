@@ -5,12 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"isup-parser/access"
+	"isup-parser/call"
+	"isup-parser/carrier"
+	"isup-parser/circuit"
+	"isup-parser/confusion"
+	"isup-parser/continuity"
 	"isup-parser/isup"
 	"isup-parser/m2pa"
 	"isup-parser/m3ua"
+	"isup-parser/mcid"
 	"isup-parser/mtp3"
+	"isup-parser/priority"
 	"isup-parser/sctp"
 
 	"github.com/google/gopacket"
@@ -111,12 +120,34 @@ func detectProtocol(ppid uint32, payload []byte) string {
 
 const version = "1.0.1"
 
+// Circuit tracking intervals, in capture time
+const (
+	snapshotInterval = 60 * time.Second // Periodic circuit state snapshot
+	ackTimeout       = 60 * time.Second // Longest wait for BLA/UBA/GRA/CGBA/CGUA (T12-T22)
+)
+
+// Call correlation timers
+const (
+	suspendTimerT2 = 3 * time.Minute // Subscriber-initiated suspension (Q.764)
+	suspendTimerT6 = 2 * time.Minute // Network-initiated suspension (Q.118 upper bound)
+)
+
+// Longest wait for the SEG completing a segmented message (Q.764 T34 upper bound)
+const segmentationTimerT34 = 4 * time.Second
+
+// Circuit and direction of a message waiting for its SEG
+type segmentKey struct {
+	opc uint32
+	dpc uint32
+	cic uint16
+}
+
 func main() {
 
 	fmt.Printf("\n>>--- WELCOME to ISUParser %s --->\n", version)
 
 	if len(os.Args) < 3 {
-		fmt.Printf("\nUsage: %s <pcap_file> <isup type (itu or ansi)>\n", os.Args[0])
+		fmt.Printf("\nUsage: %s <pcap_file> <isup type (itu, etsi or ansi)> [emergency numbers, e.g. 112,911,1XX*]\n", os.Args[0])
 		return
 	}
 
@@ -124,18 +155,34 @@ func main() {
 
 	isITU := false
 	isANSI := false
+	isETSI := false
 
 	MTP3_standard := os.Args[2]
 	switch MTP3_standard {
 	case "itu":
 		isITU = true
+	case "etsi":
+		isITU = true
+		isETSI = true
 	case "ansi":
 		isANSI = true
 	default:
-		fmt.Println("Unknown MTP3 standard specified. Use 'itu' or 'ansi'.")
+		fmt.Println("Unknown MTP3 standard specified. Use 'itu', 'etsi' or 'ansi'.")
 		return
 	}
 
+	// Called numbers tagging emergency calls
+	var emergencyPatterns []string
+	if len(os.Args) > 3 {
+		emergencyPatterns = strings.Split(os.Args[3], ",")
+	}
+
+	// ETSI ISUP uses the ITU-T format
+	parseISUP_ITU := isup.ParseISUP_ITU
+	if isETSI {
+		parseISUP_ITU = isup.ParseISUP_ETSI
+	}
+
 	// Open the pcap file
 	handle, err := pcap.OpenOffline(pcapFile)
 	if err != nil {
@@ -158,6 +205,75 @@ func main() {
 	// Start a goroutine to process JSON buffers
 	go processJSONBuffers(jsonBufferChan)
 
+	// Per-CIC circuit state tracker
+	tracker := circuit.NewTracker(ackTimeout)
+	var lastSnapshot, lastTimestamp time.Time
+
+	// Call correlator
+	correlator := call.NewCorrelator(call.Timers{T2: suspendTimerT2, T6: suspendTimerT6})
+
+	// Continuity check tracker
+	continuityTracker := continuity.NewTracker()
+
+	// Malicious call identification tracker
+	mcidTracker := mcid.NewTracker()
+
+	// CFN/UCIC tracker
+	confusionTracker := confusion.NewTracker()
+
+	// Access Transport tracker, following the Q.931 information elements across legs
+	accessTracker := access.NewTracker()
+
+	// Emergency and priority call tracker
+	priorityTracker := priority.NewTracker(emergencyPatterns)
+
+	// Traffic per selected carrier
+	carrierTracker := carrier.NewTracker()
+
+	// Segmented APM reassembly
+	apmReassembler := isup.NewAPMReassembler()
+
+	// Report a parsed message, tracking the circuit state and correlating calls
+	report := func(parsedMessage ParsedMessage) {
+		var jsonBuffer []byte
+		var callRecord *call.Record
+		if parsedMessage.ISUP != nil && parsedMessage.MTP3 != nil {
+			rl := parsedMessage.MTP3.RoutingLabel
+
+			// Complete segmented APM data before the message is reported
+			apmReassembler.Reassemble(rl.OPC, rl.DPC, parsedMessage.ISUP)
+
+			// Create JSON buffer for the complete block
+			jsonBuffer = createJSONBuffer(parsedMessage)
+
+			tracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			callRecord = correlator.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			continuityTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			mcidTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			confusionTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			accessTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			priorityTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+			carrierTracker.Update(parsedMessage.Timestamp, rl.OPC, rl.DPC, parsedMessage.ISUP)
+		}
+
+		// Send JSON buffer through channel if we have a complete ISUP block
+		if jsonBuffer != nil {
+			jsonBufferChan <- jsonBuffer
+			successfulParses++
+		}
+
+		// Send the call record once the call is complete
+		if callRecord != nil {
+			if recordBuffer := createJSONBuffer(callRecord); recordBuffer != nil {
+				jsonBufferChan <- recordBuffer
+			}
+		}
+		successfulParses++
+	}
+
+	// Messages held until the SEG completing them arrives
+	segmented := make(map[segmentKey]ParsedMessage)
+
 	// Iterate through packets
 	for packet := range packetSource.Packets() {
 		packetCount++
@@ -212,7 +328,6 @@ func main() {
 			}
 
 			// Parse based on protocol type (M2PA/M3UA logic)
-			var jsonBuffer []byte
 			switch protocol {
 			case ProtocolM2PA:
 				m2paCount++
@@ -227,10 +342,8 @@ func main() {
 								parsedMessage.MTP3 = mtp3Msg
 
 								if len(mtp3Msg.Data) > 0 {
-									if isupMsg, err := isup.ParseISUP_ITU(mtp3Msg.Data); err == nil {
+									if isupMsg, err := parseISUP_ITU(mtp3Msg.Data); err == nil {
 										parsedMessage.ISUP = isupMsg
-										// Create JSON buffer for the complete block
-										jsonBuffer = createJSONBuffer(parsedMessage)
 									}
 								}
 							}
@@ -242,8 +355,6 @@ func main() {
 								if len(mtp3Msg.Data) > 0 {
 									if isupMsg, err := isup.ParseISUP_ANSI(mtp3Msg.Data); err == nil {
 										parsedMessage.ISUP = isupMsg
-										// Create JSON buffer for complete block
-										jsonBuffer = createJSONBuffer(parsedMessage)
 									}
 								}
 							}
@@ -263,10 +374,8 @@ func main() {
 								parsedMessage.MTP3 = mtp3Msg
 
 								if len(mtp3Msg.Data) > 0 {
-									if isupMsg, err := isup.ParseISUP_ITU(mtp3Msg.Data); err == nil {
+									if isupMsg, err := parseISUP_ITU(mtp3Msg.Data); err == nil {
 										parsedMessage.ISUP = isupMsg
-										// Create JSON buffer for complete block
-										jsonBuffer = createJSONBuffer(parsedMessage)
 									}
 								}
 							}
@@ -278,8 +387,6 @@ func main() {
 								if len(mtp3Msg.Data) > 0 {
 									if isupMsg, err := isup.ParseISUP_ANSI(mtp3Msg.Data); err == nil {
 										parsedMessage.ISUP = isupMsg
-										// Create JSON buffer for complete block
-										jsonBuffer = createJSONBuffer(parsedMessage)
 									}
 								}
 							}
@@ -288,17 +395,66 @@ func main() {
 				}
 			}
 
-			// Send JSON buffer through channel if we have a complete ISUP block
-			if jsonBuffer != nil {
-				jsonBufferChan <- jsonBuffer
-				successfulParses++
+			if parsedMessage.ISUP != nil && parsedMessage.MTP3 != nil {
+				rl := parsedMessage.MTP3.RoutingLabel
+				key := segmentKey{opc: rl.OPC, dpc: rl.DPC, cic: parsedMessage.ISUP.CIC}
+
+				// A SEG completes the message held on its circuit, any other message releases it as is
+				if held, exists := segmented[key]; exists {
+					delete(segmented, key)
+					if parsedMessage.ISUP.MessageType == isup.ISUPMessageTypeSEG {
+						if err := held.ISUP.MergeSegment(parsedMessage.ISUP); err == nil {
+							report(held)
+							continue
+						}
+					}
+					report(held)
+				}
+
+				// Hold a message announcing a SEG until the SEG arrives
+				if parsedMessage.ISUP.AwaitsSegment() {
+					segmented[key] = parsedMessage
+					continue
+				}
+			}
+
+			report(parsedMessage)
+		}
+
+		// Release the messages whose SEG never came
+		for key, held := range segmented {
+			if packet.Metadata().Timestamp.Sub(held.Timestamp) >= segmentationTimerT34 {
+				delete(segmented, key)
+				report(held)
 			}
-			successfulParses++
 		}
 
 		if packetCount%100 == 0 {
 			fmt.Printf("Processed %d packets...\n", packetCount)
 		}
+
+		// Periodic circuit state snapshot
+		lastTimestamp = packet.Metadata().Timestamp
+		if lastSnapshot.IsZero() {
+			lastSnapshot = lastTimestamp
+		} else if lastTimestamp.Sub(lastSnapshot) >= snapshotInterval {
+			if snapshot := createJSONBuffer(tracker.Snapshot(lastTimestamp)); snapshot != nil {
+				jsonBufferChan <- snapshot
+			}
+			lastSnapshot = lastTimestamp
+		}
+	}
+
+	// Report the messages still waiting for their SEG
+	for _, held := range segmented {
+		report(held)
+	}
+
+	// Send the calls still in progress at the end of the capture
+	for _, callRecord := range correlator.Finish() {
+		if recordBuffer := createJSONBuffer(callRecord); recordBuffer != nil {
+			jsonBufferChan <- recordBuffer
+		}
 	}
 
 	// Close the JSON buffer channel and wait for the process to finish
@@ -308,6 +464,55 @@ func main() {
 	fmt.Printf("Processed %d packets, successfully parsed %d SIGTRAN messages\n", packetCount, successfulParses)
 	fmt.Printf("M2PA packets: %d, M3UA packets: %d\n\n", m2paCount, m3uaCount)
 
+	// Final circuit state map per trunk
+	if report := createJSONBuffer(tracker.Finish(lastTimestamp)); report != nil {
+		fmt.Printf("=== Circuit State Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Circuit State Report ===\n\n")
+	}
+
+	// Continuity check outcomes per trunk
+	if report := createJSONBuffer(continuityTracker.Finish(lastTimestamp)); report != nil {
+		fmt.Printf("=== Continuity Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Continuity Report ===\n\n")
+	}
+
+	// Malicious call identification requests and the numbers identified
+	if report := createJSONBuffer(mcidTracker.Finish()); report != nil {
+		fmt.Printf("=== MCID Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End MCID Report ===\n\n")
+	}
+
+	// CFN and UCIC with the message that triggered them
+	if report := createJSONBuffer(confusionTracker.Finish()); report != nil {
+		fmt.Printf("=== Confusion Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Confusion Report ===\n\n")
+	}
+
+	// Compatibility information and subaddresses compared between the first and last legs of a call
+	if report := createJSONBuffer(accessTracker.Finish()); report != nil {
+		fmt.Printf("=== Access Transport Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Access Transport Report ===\n\n")
+	}
+
+	// Emergency and priority calls, pre-empted or congested ones counted
+	if report := createJSONBuffer(priorityTracker.Finish()); report != nil {
+		fmt.Printf("=== Priority Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Priority Report ===\n\n")
+	}
+
+	// Calls and answered time per selected carrier, for interconnect reconciliation
+	if report := createJSONBuffer(carrierTracker.Finish()); report != nil {
+		fmt.Printf("=== Carrier Report (%d bytes) ===\n", len(report))
+		fmt.Printf("%s\n", string(report))
+		fmt.Printf("=== End Carrier Report ===\n\n")
+	}
+
 	if successfulParses == 0 {
 		fmt.Fprintf(os.Stderr, "!! No SIGTRAN messages found in the pcap file !!")
 		fmt.Fprintf(os.Stderr, "!! Verify the packet contains SCTP with M2PA/M3UA payload !!")
@@ -316,7 +521,7 @@ func main() {
 }
 
 // Helper function to create JSON buffer
-func createJSONBuffer(message ParsedMessage) []byte {
+func createJSONBuffer(message any) []byte {
 	jsonData, err := json.Marshal(message)
 	if err != nil {
 		fmt.Printf("Error creating JSON buffer: %v\n", err)
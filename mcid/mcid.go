@@ -0,0 +1,155 @@
+package mcid
+
+import (
+	"sort"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportMCID = "mcid"
+
+// Event is one malicious call identification request with its outcome
+type Event struct {
+	OPC              uint32     `json:"opc"` // IAM sender, the originating side
+	DPC              uint32     `json:"dpc"`
+	CIC              uint16     `json:"cic"`
+	CallStart        *time.Time `json:"call_start,omitempty"`
+	CalledNumber     string     `json:"called_number,omitempty"`
+	CallingNumber    string     `json:"calling_number,omitempty"` // As presented in the IAM
+	Request          time.Time  `json:"request"`
+	Response         *time.Time `json:"response,omitempty"`
+	Identified       bool       `json:"identified"` // IDS returned the calling party
+	IdentifiedNumber string     `json:"identified_number,omitempty"`
+	Presentation     string     `json:"presentation,omitempty"`
+	GenericNumber    string     `json:"generic_number,omitempty"`
+	HoldRequested    bool       `json:"hold_requested"`
+	HoldProvided     bool       `json:"hold_provided"`
+}
+
+// Report lists the MCID requests seen in the capture
+type Report struct {
+	Report string  `json:"report"`
+	Events []Event `json:"events"`
+}
+
+type circuitKey struct {
+	pcA uint32
+	pcB uint32
+	cic uint16
+}
+
+// Call on a circuit, as announced by its IAM
+type callInfo struct {
+	opc     uint32
+	dpc     uint32
+	start   time.Time
+	called  string
+	calling string
+	pending *Event // IDR waiting for its IDS
+}
+
+// Tracker links IDR and IDS to the call on the circuit
+type Tracker struct {
+	calls  map[circuitKey]*callInfo
+	events []Event
+}
+
+// NewTracker creates an MCID tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		calls: make(map[circuitKey]*callInfo),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+	pcA, pcB := opc, dpc
+	if pcA > pcB {
+		pcA, pcB = pcB, pcA
+	}
+	key := circuitKey{pcA: pcA, pcB: pcB, cic: msg.CIC}
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeIAM:
+		t.closePending(key)
+		c := &callInfo{opc: opc, dpc: dpc, start: ts}
+		if msg.IAM != nil {
+			if msg.IAM.CalledPartyNumber != nil {
+				c.called = msg.IAM.CalledPartyNumber.Number
+			}
+			if msg.IAM.CallingPartyNumber != nil {
+				c.calling = msg.IAM.CallingPartyNumber.Number
+			}
+		}
+		t.calls[key] = c
+	case isup.ISUPMessageTypeIDR:
+		c, exists := t.calls[key]
+		if !exists {
+			// IDR on a call whose IAM is not in the capture: the IDR comes from the terminating side
+			c = &callInfo{opc: dpc, dpc: opc}
+			t.calls[key] = c
+		}
+		t.closePending(key)
+		event := &Event{OPC: c.opc, DPC: c.dpc, CIC: msg.CIC, Request: ts}
+		if !c.start.IsZero() {
+			event.CallStart = &c.start
+		}
+		event.CalledNumber = c.called
+		event.CallingNumber = c.calling
+		if msg.IDR != nil && msg.IDR.Indicators != nil {
+			event.HoldRequested = msg.IDR.Indicators.Holding == 1
+		}
+		c.pending = event
+	case isup.ISUPMessageTypeIDS:
+		c, exists := t.calls[key]
+		if !exists || c.pending == nil {
+			return
+		}
+		event := c.pending
+		event.Response = &ts
+		if msg.IDS != nil {
+			if msg.IDS.Indicators != nil {
+				event.HoldProvided = msg.IDS.Indicators.HoldProvided == 1
+			}
+			if msg.IDS.CallingPartyNumber != nil {
+				event.Identified = true
+				event.IdentifiedNumber = msg.IDS.CallingPartyNumber.Number
+				event.Presentation = msg.IDS.CallingPartyNumber.RestrictName
+			}
+			if msg.IDS.GenericNumber != nil {
+				event.GenericNumber = msg.IDS.GenericNumber.Number
+			}
+		}
+		t.closePending(key)
+	case isup.ISUPMessageTypeRLC:
+		t.closePending(key)
+		delete(t.calls, key)
+	}
+}
+
+// Finish returns the MCID report, including the requests never answered
+func (t *Tracker) Finish() *Report {
+	for key := range t.calls {
+		t.closePending(key)
+	}
+
+	report := &Report{Report: ReportMCID, Events: t.events}
+	sort.Slice(report.Events, func(i, j int) bool { return report.Events[i].Request.Before(report.Events[j].Request) })
+
+	return report
+}
+
+// Record the IDR pending on the circuit, answered or not
+func (t *Tracker) closePending(key circuitKey) {
+	c, exists := t.calls[key]
+	if !exists || c.pending == nil {
+		return
+	}
+	t.events = append(t.events, *c.pending)
+	c.pending = nil
+}
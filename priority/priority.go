@@ -0,0 +1,236 @@
+package priority
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"isup-parser/isup"
+)
+
+// Report kind
+const ReportPriority = "priority"
+
+// Emergency numbers used when none are configured
+var DefaultEmergencyPatterns = []string{"112", "911", "999"}
+
+// Event is one emergency or priority call with its outcome
+type Event struct {
+	Start         time.Time  `json:"start"`
+	OPC           uint32     `json:"opc"` // IAM sender, the originating side
+	DPC           uint32     `json:"dpc"`
+	CIC           uint16     `json:"cic"`
+	CalledNumber  string     `json:"called_number,omitempty"`
+	CallingNumber string     `json:"calling_number,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	Precedence    string     `json:"precedence,omitempty"` // MLPP precedence level
+	Emergency     bool       `json:"emergency"`
+	Priority      bool       `json:"priority"`
+	Reasons       []string   `json:"reasons"` // Why the call was tagged
+	Answer        *time.Time `json:"answer,omitempty"`
+	Release       *time.Time `json:"release,omitempty"`
+	ReleasedBy    string     `json:"released_by,omitempty"` // "originating" or "terminating"
+	CauseValue    uint8      `json:"cause_value,omitempty"`
+	CauseName     string     `json:"cause_name,omitempty"`
+	Preempted     bool       `json:"preempted"`
+	Congested     bool       `json:"congested"`
+}
+
+// Report lists the emergency and priority calls seen in the capture
+type Report struct {
+	Report    string  `json:"report"`
+	Calls     int     `json:"calls"`
+	Preempted int     `json:"preempted"`
+	Congested int     `json:"congested"`
+	Events    []Event `json:"events"`
+}
+
+type circuitKey struct {
+	pcA uint32
+	pcB uint32
+	cic uint16
+}
+
+// Tracker tags emergency and priority calls and follows them until release
+type Tracker struct {
+	patterns []string
+	calls    map[circuitKey]*Event
+	events   []Event
+}
+
+// NewTracker creates a priority tracker. Emergency patterns are called numbers,
+// where X stands for any digit and a trailing * for any remaining digits.
+func NewTracker(patterns []string) *Tracker {
+	if len(patterns) == 0 {
+		patterns = DefaultEmergencyPatterns
+	}
+	return &Tracker{
+		patterns: patterns,
+		calls:    make(map[circuitKey]*Event),
+	}
+}
+
+// Update applies an ISUP message sent from opc to dpc
+func (t *Tracker) Update(ts time.Time, opc, dpc uint32, msg *isup.ISUPMessage) {
+	if msg == nil {
+		return
+	}
+	pcA, pcB := opc, dpc
+	if pcA > pcB {
+		pcA, pcB = pcB, pcA
+	}
+	key := circuitKey{pcA: pcA, pcB: pcB, cic: msg.CIC}
+
+	switch msg.MessageType {
+	case isup.ISUPMessageTypeIAM:
+		t.close(key)
+		if msg.IAM == nil {
+			return
+		}
+		if event := t.tag(msg.IAM, msg.Variant()); event != nil {
+			event.Start = ts
+			event.OPC = opc
+			event.DPC = dpc
+			event.CIC = msg.CIC
+			t.calls[key] = event
+		}
+	case isup.ISUPMessageTypeANM, isup.ISUPMessageTypeCON:
+		if event, exists := t.calls[key]; exists && event.Answer == nil {
+			event.Answer = &ts
+		}
+	case isup.ISUPMessageTypeREL:
+		event, exists := t.calls[key]
+		if !exists {
+			return
+		}
+		event.Release = &ts
+		event.ReleasedBy = "terminating"
+		if opc == event.OPC {
+			event.ReleasedBy = "originating"
+		}
+		if msg.REL != nil && msg.REL.Cause != nil {
+			event.CauseValue = msg.REL.Cause.CauseValue
+			event.CauseName = msg.REL.Cause.CauseName
+			event.Preempted = isPreemption(event.CauseValue)
+			event.Congested = isCongestion(event.CauseValue)
+		}
+		t.close(key)
+	case isup.ISUPMessageTypeRSC:
+		t.close(key)
+	}
+}
+
+// Finish returns the priority report, including calls still up at the end of the capture
+func (t *Tracker) Finish() *Report {
+	for key := range t.calls {
+		t.close(key)
+	}
+
+	report := &Report{Report: ReportPriority, Events: t.events}
+	sort.Slice(report.Events, func(i, j int) bool { return report.Events[i].Start.Before(report.Events[j].Start) })
+	for _, event := range report.Events {
+		report.Calls++
+		if event.Preempted {
+			report.Preempted++
+		}
+		if event.Congested {
+			report.Congested++
+		}
+	}
+
+	return report
+}
+
+// Tag a call from its IAM, nil when it is neither an emergency nor a priority call.
+// IEPS is an ITU category, NS/EP an ANSI one: each is only checked in its own variant.
+func (t *Tracker) tag(iam *isup.IAMParameters, variant isup.Variant) *Event {
+	event := &Event{}
+	if iam.CalledPartyNumber != nil {
+		event.CalledNumber = iam.CalledPartyNumber.Number
+	}
+	if iam.CallingPartyNumber != nil {
+		event.CallingNumber = iam.CallingPartyNumber.Number
+	}
+
+	if iam.CallingPartyCategory != nil {
+		event.Category = iam.CallingPartyCategory.Name
+		ansi := variant == isup.VariantANSI
+		switch {
+		case iam.CallingPartyCategory.Num == isup.CategoryPriority:
+			event.Priority = true
+			event.Reasons = append(event.Reasons, "calling subscriber with priority")
+		case iam.CallingPartyCategory.Num == isup.CategoryIEPS && !ansi:
+			event.Priority = true
+			event.Reasons = append(event.Reasons, "IEPS call marking")
+		case iam.CallingPartyCategory.Num == isup.CategoryNSEP && ansi:
+			event.Priority = true
+			event.Reasons = append(event.Reasons, "national security and emergency preparedness")
+		}
+	}
+
+	if iam.MLPPPrecedence != nil {
+		event.Precedence = iam.MLPPPrecedence.LevelName
+		if iam.MLPPPrecedence.Level < isup.PrecedenceRoutine {
+			event.Priority = true
+			event.Reasons = append(event.Reasons, "MLPP precedence "+iam.MLPPPrecedence.LevelName)
+		}
+	}
+
+	for _, pattern := range t.patterns {
+		if matchNumber(pattern, event.CalledNumber) {
+			event.Emergency = true
+			event.Reasons = append(event.Reasons, "emergency number "+pattern)
+			break
+		}
+	}
+
+	if !event.Emergency && !event.Priority {
+		return nil
+	}
+	return event
+}
+
+// Record the call on the circuit, released or not
+func (t *Tracker) close(key circuitKey) {
+	event, exists := t.calls[key]
+	if !exists {
+		return
+	}
+	t.events = append(t.events, *event)
+	delete(t.calls, key)
+}
+
+// Match a called number against a pattern: X is any digit, a trailing * any remaining digits
+func matchNumber(pattern, number string) bool {
+	if number == "" {
+		return false
+	}
+	prefix := strings.HasSuffix(pattern, "*")
+	pattern = strings.TrimSuffix(pattern, "*")
+	if len(number) < len(pattern) || (!prefix && len(number) != len(pattern)) {
+		return false
+	}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != 'X' && pattern[i] != 'x' && pattern[i] != number[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isPreemption(cause uint8) bool {
+	switch cause {
+	case isup.CausePreemption, isup.CausePreemptionCircuitReserved:
+		return true
+	}
+	return false
+}
+
+func isCongestion(cause uint8) bool {
+	switch cause {
+	case isup.CauseNoCircuitAvailable, isup.CauseSwitchingCongestion, isup.CauseRequestedCircuitNotAvail,
+		isup.CausePrecedenceCallBlocked, isup.CauseResourceUnavailable:
+		return true
+	}
+	return false
+}